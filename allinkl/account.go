@@ -0,0 +1,65 @@
+package allinkl
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AccountResource is a resource included in the package of an account, e.g.
+// databases or mail accounts.
+type AccountResource struct {
+	// Name is the resource name as used by KAS without the max_ prefix,
+	// e.g. database or mail_account.
+	Name string
+	// Max is the number of resources the package includes. It is -1 if
+	// the package includes an unlimited number.
+	Max int64
+	// Used is the number of resources in use.
+	Used int64
+}
+
+// accountResources converts the ReturnInfo of get_accountresources, keyed
+// by max_<name>, into resources ordered by name. KAS reports a resource
+// either as its limit or as a map of its limit (max) and usage (used).
+func accountResources(info map[string]any) []AccountResource {
+	var resources []AccountResource
+	for key, value := range info {
+		name, ok := strings.CutPrefix(key, "max_")
+		if !ok {
+			continue
+		}
+
+		resource := AccountResource{Name: name}
+		if values, ok := value.(map[string]any); ok {
+			resource.Max = resourceCount(values["max"])
+			resource.Used = resourceCount(values["used"])
+		} else {
+			resource.Max = resourceCount(value)
+		}
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Name < resources[j].Name
+	})
+	return resources
+}
+
+// resourceCount converts a resource count of a KAS response. Counts that
+// are not numeric, such as "unlimited", are reported as -1.
+func resourceCount(value any) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	case string:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return -1
+		}
+		return n
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,385 @@
+package allinkl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+const apiEndpoint = "https://kasapi.kasserver.com/soap/KasApi.php"
+
+// defaultRequestTimeout bounds a single KAS request, or less if the caller's
+// context has an earlier deadline. Reads of zones of known size use a timeout
+// scaled with the zone size instead.
+const defaultRequestTimeout = 30 * time.Second
+
+// Authentication is implemented by KAS authentication providers.
+type Authentication interface {
+	Authentication(ctx context.Context, sessionLifetime int, sessionUpdateLifetime bool) (string, error)
+}
+
+// Client a KAS server client.
+type Client struct {
+	identifier  *Identifier
+	floodTime   time.Time
+	muFloodTime sync.Mutex
+	baseURL     string
+	zoneSizes   zoneSizes
+	session     session
+	HTTPClient  *http.Client
+
+	// EventHandler, if set, is notified before the client waits for flood
+	// delays or retries.
+	EventHandler EventHandler
+
+	// StrictDecoding fails requests whose responses contain fields the
+	// client does not know instead of ignoring them.
+	StrictDecoding bool
+
+	// MinRequestInterval is the minimum time between two requests, applied
+	// when KAS requests a shorter flood delay.
+	MinRequestInterval time.Duration
+}
+
+// NewClient creates a client authenticating with the given KAS login and
+// password.
+func NewClient(username string, password string) *Client {
+	return &Client{
+		identifier: NewIdentifier(username, password),
+		baseURL:    apiEndpoint,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// NewClientWithEndpoints creates a client like NewClient that talks to the
+// given KAS API and authentication endpoints, e.g. of a mock server.
+func NewClientWithEndpoints(username, password, apiEndpoint, authEndpoint string) *Client {
+	client := NewClient(username, password)
+	client.baseURL = apiEndpoint
+	client.identifier.authEndpoint = authEndpoint
+	return client
+}
+
+// APIEndpoint returns the URL of the KAS API the client sends requests to.
+func (c *Client) APIEndpoint() string {
+	return c.baseURL
+}
+
+// AuthEndpoint returns the URL of the KAS authentication API the client
+// obtains session tokens from.
+func (c *Client) AuthEndpoint() string {
+	return c.identifier.authEndpoint
+}
+
+// GetDNSSettings returns the records of a zone (get_dns_settings). If
+// recordID is not empty only that record is returned.
+func (c *Client) GetDNSSettings(ctx context.Context, zone, recordID string) ([]ReturnInfo, error) {
+	requestParams := map[string]string{"zone_host": zone}
+	if recordID != "" {
+		requestParams["record_id"] = recordID
+	}
+
+	ctx = withRequestTimeout(ctx, c.zoneSizes.readTimeout(zone))
+
+	var g GetDNSSettingsAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "get_dns_settings", requestParams)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	if recordID == "" {
+		c.zoneSizes.observe(zone, len(g.Response.ReturnInfo))
+	}
+	return g.Response.ReturnInfo, nil
+}
+
+// AddDNSSettings creates a record (add_dns_settings) and returns its ID.
+func (c *Client) AddDNSSettings(ctx context.Context, record DNSRequest) (string, error) {
+	var g AddDNSSettingsAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "add_dns_settings", record)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return "", err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return g.Response.ReturnInfo, nil
+}
+
+// UpdateDNSSettings updates the record identified by record.RecordId
+// (update_dns_settings).
+func (c *Client) UpdateDNSSettings(ctx context.Context, record DNSRequest) (string, error) {
+	var g AddDNSSettingsAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "update_dns_settings", record)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return "", err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return g.Response.ReturnInfo, nil
+}
+
+// DeleteDNSSettings deletes a record (delete_dns_settings).
+func (c *Client) DeleteDNSSettings(ctx context.Context, recordID string) (DeleteDNSSettingsResponse, error) {
+	requestParams := map[string]string{"record_id": recordID}
+
+	var g DeleteDNSSettingsAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "delete_dns_settings", requestParams)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return DeleteDNSSettingsResponse{}, err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return g.Response, nil
+}
+
+// GetDomains returns the domains of the account (get_domains).
+func (c *Client) GetDomains(ctx context.Context) ([]Domain, error) {
+	var g GetDomainsAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "get_domains", map[string]string{})
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return g.Response.ReturnInfo, nil
+}
+
+// GetSubdomains returns the subdomains of the account (get_subdomains).
+func (c *Client) GetSubdomains(ctx context.Context) ([]Subdomain, error) {
+	var g GetSubdomainsAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "get_subdomains", map[string]string{})
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return g.Response.ReturnInfo, nil
+}
+
+// GetAccountResources returns the resources the package of the account
+// includes and how many of them are in use (get_accountresources).
+func (c *Client) GetAccountResources(ctx context.Context) ([]AccountResource, error) {
+	var g GetAccountResourcesAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "get_accountresources", map[string]string{})
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return accountResources(g.Response.ReturnInfo), nil
+}
+
+func (c *Client) newRequest(ctx context.Context, action string, requestParams any) (*http.Request, error) {
+	ar := KasRequest{
+		Login:         c.identifier.login,
+		AuthType:      "session",
+		AuthData:      getToken(ctx),
+		Action:        action,
+		RequestParams: requestParams,
+	}
+	body, err := json.Marshal(ar)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request JSON body: %w", err)
+	}
+	payload := []byte(strings.TrimSpace(fmt.Sprintf(kasAPIEnvelope, body)))
+	req, err := http.NewRequestWithContext(withAction(ctx, action), http.MethodPost, c.baseURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request, result any) error {
+	ctx := req.Context()
+	onRetry := func(attempt int, wait time.Duration, err error) {
+		c.emit(ctx, Event{Kind: EventRetry, Action: getAction(ctx), Attempt: attempt + 1, Wait: wait, Err: err})
+	}
+
+	return retry(ctx, func(attempt int) error {
+		clone := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return fmt.Errorf("unable to rewind request body: %w", err)
+			}
+			clone.Body = body
+		}
+		return c.doOnce(clone, attempt, result)
+	}, onRetry)
+}
+
+func (c *Client) doOnce(req *http.Request, attempt int, result any) error {
+	// Every attempt gets the request timeout, ending earlier if the
+	// deadline of the operation comes first.
+	ctx, cancel := context.WithTimeout(req.Context(), getRequestTimeout(req.Context()))
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	c.muFloodTime.Lock()
+	if wait := time.Until(c.floodTime); wait > 0 {
+		c.emit(ctx, Event{Kind: EventFloodDelay, Action: getAction(ctx), Attempt: attempt, Wait: wait})
+	}
+	err := sleepUntil(ctx, c.floodTime)
+	c.muFloodTime.Unlock()
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return NewHTTPDoError(req, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return NewUnexpectedResponseStatusCodeError(req, resp)
+	}
+	envlp, err := decodeXML[KasAPIResponseEnvelope](resp.Body)
+	if err != nil {
+		return err
+	}
+	if envlp.Body.Fault != nil {
+		envlp.Body.Fault.Action = getAction(ctx)
+		return envlp.Body.Fault
+	}
+	raw := getValue(envlp.Body.KasAPIResponse.Return)
+	if recorder := getResponseRecorder(req.Context()); recorder != nil {
+		recorder.record(raw)
+	}
+	err = c.decode(raw, result)
+	if err != nil {
+		return fmt.Errorf("response struct decode: %w", err)
+	}
+	return nil
+}
+
+// decode decodes a response value into result, rejecting unknown fields if
+// StrictDecoding is set.
+func (c *Client) decode(raw, result any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:      result,
+		ErrorUnused: c.StrictDecoding,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(raw)
+}
+
+// sleepUntil waits until t has passed or ctx is done.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for KAS flood delay: %w", ctx.Err())
+	}
+}
+
+func (c *Client) updateFloodTime(delay float64) {
+	wait := time.Duration(delay * float64(time.Second))
+	if wait < c.MinRequestInterval {
+		wait = c.MinRequestInterval
+	}
+
+	c.muFloodTime.Lock()
+	c.floodTime = time.Now().Add(wait)
+	c.muFloodTime.Unlock()
+}
+
+func getValue(item *Item) any {
+	switch {
+	case item.Raw != "":
+		v, _ := strconv.ParseBool(item.Raw)
+		return v
+	case item.Text != "":
+		switch item.Type {
+		case "xsd:string":
+			return item.Text
+		case "xsd:float":
+			v, _ := strconv.ParseFloat(item.Text, 64)
+			return v
+		case "xsd:int":
+			v, _ := strconv.ParseInt(item.Text, 10, 64)
+			return v
+		case "xsd:boolean":
+			v, _ := strconv.ParseBool(item.Text)
+			return v
+		default:
+			return item.Text
+		}
+	case item.Value != nil:
+		return getValue(item.Value)
+	case item.Type == "SOAP-ENC:Array":
+		// Empty arrays, e.g. of an account without subdomains, decode to
+		// an empty slice rather than an empty string.
+		v := []any{}
+		for _, i := range item.Items {
+			v = append(v, getValue(i))
+		}
+		return v
+	case len(item.Items) > 0 || item.Type == "ns2:Map":
+		v := map[string]any{}
+		for _, i := range item.Items {
+			v[getKey(i)] = getValue(i)
+		}
+		return v
+	default:
+		return ""
+	}
+}
+
+func getKey(item *Item) string {
+	if item.Key == nil {
+		return ""
+	}
+	return item.Key.Text
+}
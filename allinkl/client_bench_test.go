@@ -0,0 +1,120 @@
+package allinkl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchmarkZoneSizes are the numbers of records of the zone fixtures. KAS
+// zones rarely exceed a few hundred records; the largest size shows how the
+// decoding scales.
+var benchmarkZoneSizes = []int{10, 1000, 10000}
+
+// zoneResponseFixture returns a get_dns_settings response envelope of a zone
+// with the given number of records, in the format KAS sends.
+func zoneResponseFixture(records int) []byte {
+	var items strings.Builder
+	for i := 0; i < records; i++ {
+		fmt.Fprintf(&items, `<item xsi:type="ns2:Map">`+
+			`<item><key xsi:type="xsd:string">record_zone</key><value xsi:type="xsd:string">example.com</value></item>`+
+			`<item><key xsi:type="xsd:string">record_name</key><value xsi:type="xsd:string">host%d</value></item>`+
+			`<item><key xsi:type="xsd:string">record_type</key><value xsi:type="xsd:string">A</value></item>`+
+			`<item><key xsi:type="xsd:string">record_data</key><value xsi:type="xsd:string">192.0.2.%d</value></item>`+
+			`<item><key xsi:type="xsd:string">record_aux</key><value xsi:type="xsd:int">0</value></item>`+
+			`<item><key xsi:type="xsd:string">record_id</key><value xsi:type="xsd:string">%d</value></item>`+
+			`<item><key xsi:type="xsd:string">record_changeable</key><value xsi:type="xsd:string">Y</value></item>`+
+			`</item>`, i, i%256, 100000+i)
+	}
+
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:SOAP-ENC="http://schemas.xmlsoap.org/soap/encoding/" xmlns:ns1="https://kasserver.com/" xmlns:ns2="http://xml.apache.org/xml-soap">
+<SOAP-ENV:Body><ns1:KasApiResponse><return xsi:type="ns2:Map">`+
+		`<item><key xsi:type="xsd:string">Request</key><value xsi:type="ns2:Map">`+
+		`<item><key xsi:type="xsd:string">KasRequestType</key><value xsi:type="xsd:string">get_dns_settings</value></item>`+
+		`</value></item>`+
+		`<item><key xsi:type="xsd:string">Response</key><value xsi:type="ns2:Map">`+
+		`<item><key xsi:type="xsd:string">KasFloodDelay</key><value xsi:type="xsd:float">0.5</value></item>`+
+		`<item><key xsi:type="xsd:string">ReturnInfo</key><value SOAP-ENC:arrayType="ns2:Map[%d]" xsi:type="SOAP-ENC:Array">%s</value></item>`+
+		`<item><key xsi:type="xsd:string">ReturnString</key><value xsi:type="xsd:string">TRUE</value></item>`+
+		`</value></item>`+
+		`</return></ns1:KasApiResponse></SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`, records, items.String()))
+}
+
+func BenchmarkNewRequest(b *testing.B) {
+	client := NewClient("login", "password")
+	ctx := WithContext(context.Background(), "token")
+	record := DNSRequest{
+		ZoneHost:   "example.com.",
+		RecordType: "TXT",
+		RecordName: "_acme-challenge",
+		RecordData: strings.Repeat("x", 255),
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.newRequest(ctx, "add_dns_settings", record); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeXML(b *testing.B) {
+	for _, size := range benchmarkZoneSizes {
+		fixture := zoneResponseFixture(size)
+		b.Run(fmt.Sprintf("records=%d", size), func(b *testing.B) {
+			b.SetBytes(int64(len(fixture)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := decodeXML[KasAPIResponseEnvelope](bytes.NewReader(fixture)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetValue(b *testing.B) {
+	for _, size := range benchmarkZoneSizes {
+		envelope, err := decodeXML[KasAPIResponseEnvelope](bytes.NewReader(zoneResponseFixture(size)))
+		if err != nil {
+			b.Fatal(err)
+		}
+		item := envelope.Body.KasAPIResponse.Return
+		b.Run(fmt.Sprintf("records=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				getValue(item)
+			}
+		})
+	}
+}
+
+// BenchmarkGetDNSSettingsResponse measures the whole decoding of a response
+// body, from XML to the GetDNSSettingsAPIResponse struct.
+func BenchmarkGetDNSSettingsResponse(b *testing.B) {
+	client := NewClient("login", "password")
+	for _, size := range benchmarkZoneSizes {
+		fixture := zoneResponseFixture(size)
+		b.Run(fmt.Sprintf("records=%d", size), func(b *testing.B) {
+			b.SetBytes(int64(len(fixture)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				envelope, err := decodeXML[KasAPIResponseEnvelope](bytes.NewReader(fixture))
+				if err != nil {
+					b.Fatal(err)
+				}
+				var response GetDNSSettingsAPIResponse
+				if err := client.decode(getValue(envelope.Body.KasAPIResponse.Return), &response); err != nil {
+					b.Fatal(err)
+				}
+				if len(response.Response.ReturnInfo) != size {
+					b.Fatalf("decoded %d records, want %d", len(response.Response.ReturnInfo), size)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package allinkl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestTimeoutWithinDeadline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	// The operation deadline is far away, but the request still times out.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ctx = withRequestTimeout(ctx, 50*time.Millisecond)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClientWithEndpoints("login", "password", server.URL, server.URL)
+	start := time.Now()
+	err = client.doOnce(req, 1, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("doOnce = %v, want a deadline exceeded error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("doOnce took %s, want the request timeout", elapsed)
+	}
+}
@@ -0,0 +1,15 @@
+// Package allinkl is a client for the All-Inkl KAS SOAP API.
+//
+// The package is used by the Terraform provider and can be imported by other
+// Go tooling, such as DNS hooks for ACME clients, that needs to talk to KAS
+// without re-implementing its SOAP envelopes, session handling and flood
+// protection.
+//
+// # Stability
+//
+// The exported API of this package follows semantic versioning together with
+// the provider releases: within a major version, exported identifiers are
+// neither removed nor changed incompatibly. Every method issuing requests
+// accepts a context.Context, which controls cancellation and carries the
+// optional per-call settings of this package (see WithResponseRecorder).
+package allinkl
@@ -0,0 +1,54 @@
+package allinkl
+
+import (
+	"context"
+	"time"
+)
+
+// EventKind identifies why the client is waiting.
+type EventKind string
+
+const (
+	// EventFloodDelay is emitted before the client waits for the flood delay
+	// KAS requested with the previous response.
+	EventFloodDelay EventKind = "flood_delay"
+	// EventRetry is emitted before the client waits to retry a request that
+	// failed with a transient error.
+	EventRetry EventKind = "retry"
+)
+
+// Event describes a wait of the client, for logging where time goes during
+// slow operations.
+type Event struct {
+	Kind EventKind
+	// Action is the KAS action of the request, e.g. get_dns_settings.
+	Action string
+	// Attempt is the attempt of the request the wait precedes, starting at 1.
+	Attempt int
+	// Wait is the duration the client is about to wait.
+	Wait time.Duration
+	// Err is the error being retried, nil for flood delays.
+	Err error
+}
+
+// EventHandler receives the events of a Client. It must not block.
+type EventHandler func(ctx context.Context, event Event)
+
+type actionKey string
+
+const requestActionKey actionKey = "action"
+
+func withAction(ctx context.Context, action string) context.Context {
+	return context.WithValue(ctx, requestActionKey, action)
+}
+
+func getAction(ctx context.Context) string {
+	action, _ := ctx.Value(requestActionKey).(string)
+	return action
+}
+
+func (c *Client) emit(ctx context.Context, event Event) {
+	if c.EventHandler != nil {
+		c.EventHandler(ctx, event)
+	}
+}
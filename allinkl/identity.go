@@ -16,6 +16,7 @@ type token string
 
 const tokenKey token = "token"
 
+// Identifier obtains KAS session tokens for a login.
 type Identifier struct {
 	login        string
 	password     string
@@ -23,6 +24,7 @@ type Identifier struct {
 	HTTPClient   *http.Client
 }
 
+// NewIdentifier creates an Identifier for the given KAS login and password.
 func NewIdentifier(login string, password string) *Identifier {
 	return &Identifier{
 		login:        login,
@@ -32,6 +34,8 @@ func NewIdentifier(login string, password string) *Identifier {
 	}
 }
 
+// Authentication returns the session token carried by ctx, or requests a new
+// session token from KAS.
 func (c *Identifier) Authentication(ctx context.Context) (string, error) {
 	if token := getToken(ctx); token != "" {
 		return token, nil
@@ -41,7 +45,7 @@ func (c *Identifier) Authentication(ctx context.Context) (string, error) {
 		Login:                 c.login,
 		AuthData:              c.password,
 		AuthType:              "plain",
-		SessionLifetime:       300,
+		SessionLifetime:       sessionLifetime,
 		SessionUpdateLifetime: "Y",
 	}
 	body, err := json.Marshal(ar)
@@ -70,9 +74,12 @@ func (c *Identifier) Authentication(ctx context.Context) (string, error) {
 	}
 	return envlp.Body.KasAuthResponse.Return.Text, nil
 }
+
+// WithContext returns a context carrying the KAS session token credential.
 func WithContext(ctx context.Context, credential string) context.Context {
 	return context.WithValue(ctx, tokenKey, credential)
 }
+
 func getToken(ctx context.Context) string {
 	credential, ok := ctx.Value(tokenKey).(string)
 	if !ok {
@@ -0,0 +1,284 @@
+package allinkl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// MailAccountRequest holds the parameters of add_mailaccount and
+// update_mailaccount.
+type MailAccountRequest struct {
+	// Login the KAS login of the mail account, e.g. m0123456. Only set
+	// for updates.
+	Login string `json:"mail_login,omitempty"`
+	// Password the password of a new mail account.
+	Password string `json:"mail_password,omitempty"`
+	// NewPassword the new password of an updated mail account, empty to
+	// keep the password.
+	NewPassword string `json:"mail_new_password,omitempty"`
+	// LocalPart the part of the address before the @. Only set on create.
+	LocalPart string `json:"local_part,omitempty"`
+	// DomainPart the domain of the address. Only set on create.
+	DomainPart string `json:"domain_part,omitempty"`
+	// Quota the mailbox size in MB, 0 for the default of the package.
+	Quota int64 `json:"mail_quota,omitempty"`
+}
+
+// MailAccount a mail account of the account.
+type MailAccount struct {
+	Login string `json:"mail_login" mapstructure:"mail_login"`
+	// Addresses the comma separated addresses delivered to the mail
+	// account.
+	Addresses string `json:"mail_adresses,omitempty" mapstructure:"mail_adresses"`
+	Quota     any    `json:"mail_quota,omitempty" mapstructure:"mail_quota"`
+	// Other holds the mail account settings the client does not use, e.g.
+	// the responder and copy settings.
+	Other map[string]any `json:"-" mapstructure:",remain"`
+}
+
+// Address returns the first address of the mail account.
+func (m MailAccount) Address() string {
+	address, _, _ := strings.Cut(m.Addresses, ",")
+	return strings.TrimSpace(address)
+}
+
+// QuotaMB returns the mailbox size in MB, -1 if unlimited.
+func (m MailAccount) QuotaMB() int64 {
+	return resourceCount(m.Quota)
+}
+
+type GetMailAccountsAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any                     `json:"Request" mapstructure:"Request"`
+	Response GetMailAccountsResponse `json:"Response" mapstructure:"Response"`
+}
+
+type GetMailAccountsResponse struct {
+	KasFloodDelay float64       `json:"KasFloodDelay" mapstructure:"KasFloodDelay"`
+	ReturnInfo    []MailAccount `json:"ReturnInfo" mapstructure:"ReturnInfo"`
+	ReturnString  string        `json:"ReturnString" mapstructure:"ReturnString"`
+}
+
+// MailAPIResponse is the response of the mail actions that return no
+// data besides a login or success flag.
+type MailAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any          `json:"Request" mapstructure:"Request"`
+	Response MailResponse `json:"Response" mapstructure:"Response"`
+}
+
+type MailResponse struct {
+	KasFloodDelay float64 `json:"KasFloodDelay" mapstructure:"KasFloodDelay"`
+	ReturnInfo    any     `json:"ReturnInfo" mapstructure:"ReturnInfo"`
+	ReturnString  string  `json:"ReturnString" mapstructure:"ReturnString"`
+}
+
+// GetMailAccounts returns the mail accounts of the account
+// (get_mailaccounts). If login is not empty only that mail account is
+// returned.
+func (c *Client) GetMailAccounts(ctx context.Context, login string) ([]MailAccount, error) {
+	requestParams := map[string]string{}
+	if login != "" {
+		requestParams["mail_login"] = login
+	}
+
+	var g GetMailAccountsAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "get_mailaccounts", requestParams)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return g.Response.ReturnInfo, nil
+}
+
+// AddMailAccount creates a mail account (add_mailaccount) and returns its
+// login.
+func (c *Client) AddMailAccount(ctx context.Context, account MailAccountRequest) (string, error) {
+	var g MailAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "add_mailaccount", account)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return "", err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	login, _ := g.Response.ReturnInfo.(string)
+	return login, nil
+}
+
+// UpdateMailAccount updates the mail account identified by account.Login
+// (update_mailaccount).
+func (c *Client) UpdateMailAccount(ctx context.Context, account MailAccountRequest) error {
+	var g MailAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "update_mailaccount", account)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return nil
+}
+
+// DeleteMailAccount deletes a mail account (delete_mailaccount).
+func (c *Client) DeleteMailAccount(ctx context.Context, login string) error {
+	requestParams := map[string]string{"mail_login": login}
+
+	var g MailAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "delete_mailaccount", requestParams)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return nil
+}
+
+// MailForward a mail forward of the account.
+type MailForward struct {
+	Address string `json:"mail_forward_adress" mapstructure:"mail_forward_adress"`
+	// Targets the comma separated addresses mail is forwarded to.
+	Targets string `json:"mail_forward_targets,omitempty" mapstructure:"mail_forward_targets"`
+	// Other holds the mail forward settings the client does not use, e.g.
+	// the spam filter settings.
+	Other map[string]any `json:"-" mapstructure:",remain"`
+}
+
+// TargetList returns the addresses mail is forwarded to.
+func (m MailForward) TargetList() []string {
+	var targets []string
+	for _, target := range strings.Split(m.Targets, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+type GetMailForwardsAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any                     `json:"Request" mapstructure:"Request"`
+	Response GetMailForwardsResponse `json:"Response" mapstructure:"Response"`
+}
+
+type GetMailForwardsResponse struct {
+	KasFloodDelay float64       `json:"KasFloodDelay" mapstructure:"KasFloodDelay"`
+	ReturnInfo    []MailForward `json:"ReturnInfo" mapstructure:"ReturnInfo"`
+	ReturnString  string        `json:"ReturnString" mapstructure:"ReturnString"`
+}
+
+// mailForwardTargets returns the target_<n> parameters KAS expects for the
+// targets of a mail forward.
+func mailForwardTargets(requestParams map[string]string, targets []string) map[string]string {
+	for i, target := range targets {
+		requestParams["target_"+strconv.Itoa(i)] = target
+	}
+	return requestParams
+}
+
+// GetMailForwards returns the mail forwards of the account
+// (get_mailforwards). If address is not empty only that mail forward is
+// returned.
+func (c *Client) GetMailForwards(ctx context.Context, address string) ([]MailForward, error) {
+	requestParams := map[string]string{}
+	if address != "" {
+		requestParams["mail_forward"] = address
+	}
+
+	var g GetMailForwardsAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "get_mailforwards", requestParams)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return g.Response.ReturnInfo, nil
+}
+
+// AddMailForward creates a mail forward of localPart at domainPart to
+// targets (add_mailforward).
+func (c *Client) AddMailForward(ctx context.Context, localPart, domainPart string, targets []string) error {
+	requestParams := mailForwardTargets(map[string]string{
+		"local_part":  localPart,
+		"domain_part": domainPart,
+	}, targets)
+
+	var g MailAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "add_mailforward", requestParams)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return nil
+}
+
+// UpdateMailForward replaces the targets of the mail forward of address
+// (update_mailforward).
+func (c *Client) UpdateMailForward(ctx context.Context, address string, targets []string) error {
+	requestParams := mailForwardTargets(map[string]string{"mail_forward": address}, targets)
+
+	var g MailAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "update_mailforward", requestParams)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return nil
+}
+
+// DeleteMailForward deletes the mail forward of address
+// (delete_mailforward).
+func (c *Client) DeleteMailForward(ctx context.Context, address string) error {
+	requestParams := map[string]string{"mail_forward": address}
+
+	var g MailAPIResponse
+	err := c.withSession(ctx, func(ctx context.Context) error {
+		req, err := c.newRequest(ctx, "delete_mailforward", requestParams)
+		if err != nil {
+			return err
+		}
+		return c.do(req, &g)
+	})
+	if err != nil {
+		return err
+	}
+	c.updateFloodTime(g.Response.KasFloodDelay)
+	return nil
+}
@@ -0,0 +1,83 @@
+package allinkl
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+type recorderKey string
+
+const responseRecorderKey recorderKey = "response_recorder"
+
+// redacted replaces sensitive values in recorded responses.
+const redacted = "REDACTED"
+
+// ResponseRecorder collects the decoded KAS responses of requests made with a
+// context returned by WithResponseRecorder.
+type ResponseRecorder struct {
+	mu        sync.Mutex
+	responses []any
+}
+
+// Responses returns the recorded responses in the order they were received.
+func (r *ResponseRecorder) Responses() []any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]any(nil), r.responses...)
+}
+
+func (r *ResponseRecorder) record(response any) {
+	r.mu.Lock()
+	r.responses = append(r.responses, redact(response))
+	r.mu.Unlock()
+}
+
+// WithResponseRecorder returns a context that records every decoded KAS
+// response into recorder.
+func WithResponseRecorder(ctx context.Context, recorder *ResponseRecorder) context.Context {
+	return context.WithValue(ctx, responseRecorderKey, recorder)
+}
+
+func getResponseRecorder(ctx context.Context) *ResponseRecorder {
+	recorder, ok := ctx.Value(responseRecorderKey).(*ResponseRecorder)
+	if !ok {
+		return nil
+	}
+	return recorder
+}
+
+// redact returns a copy of a decoded response with credentials and session
+// tokens replaced.
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				out[key] = redacted
+				continue
+			}
+			out[key] = redact(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redact(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, marker := range []string{"auth_data", "password", "token", "session"} {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package allinkl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type retryKey string
+
+const retryPolicyKey retryKey = "retry_policy"
+
+// RetryPolicy controls how often requests failing with a transient error are
+// retried. The delay between attempts starts at MinBackoff and doubles up to
+// MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; values below 2 disable
+	// retries.
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// backoff returns the delay before the given retry, starting at 1.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// WithRetryPolicy returns a context retrying the KAS requests made with it
+// according to policy.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey, policy)
+}
+
+func getRetryPolicy(ctx context.Context) RetryPolicy {
+	policy, ok := ctx.Value(retryPolicyKey).(RetryPolicy)
+	if !ok {
+		return RetryPolicy{MaxAttempts: 1}
+	}
+	return policy
+}
+
+// IsTransient reports whether err is likely to go away when the request is
+// repeated: network errors, gateway and availability status codes, and the
+// KAS flood protection fault.
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var doErr *HTTPDoError
+	if errors.As(err, &doErr) {
+		return true
+	}
+
+	var statusErr *UnexpectedStatusCodeError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var fault *Fault
+	if errors.As(err, &fault) {
+		return fault.Message == "flood_protection"
+	}
+
+	return false
+}
+
+// isIdempotent reports whether performing action twice has the same effect
+// as performing it once. KAS add actions create a new object on every call.
+func isIdempotent(action string) bool {
+	return !strings.HasPrefix(action, "add_")
+}
+
+// retryable reports whether a request of action failing with err may be
+// repeated. A request that is not idempotent may have been carried out even
+// though its response was lost, so it is only repeated after a flood
+// protection fault, with which KAS rejects the request without carrying it
+// out.
+func retryable(action string, err error) bool {
+	if isIdempotent(action) {
+		return IsTransient(err)
+	}
+
+	var fault *Fault
+	return errors.As(err, &fault) && fault.Message == "flood_protection"
+}
+
+// retry calls fn until it succeeds, fails with an error that is not
+// retryable or the attempts of the policy carried by ctx are exhausted.
+// onRetry is called before waiting for the next attempt.
+func retry(ctx context.Context, fn func(attempt int) error, onRetry func(attempt int, wait time.Duration, err error)) error {
+	policy := getRetryPolicy(ctx)
+	for attempt := 1; ; attempt++ {
+		err := fn(attempt)
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(getAction(ctx), err) {
+			return err
+		}
+
+		wait := policy.backoff(attempt)
+		onRetry(attempt, wait, err)
+		if waitErr := sleepUntil(ctx, time.Now().Add(wait)); waitErr != nil {
+			return fmt.Errorf("%w (retrying after: %w)", waitErr, err)
+		}
+	}
+}
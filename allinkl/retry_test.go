@@ -0,0 +1,45 @@
+package allinkl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestRetryable(t *testing.T) {
+	t.Parallel()
+
+	req, _ := http.NewRequest(http.MethodPost, "https://kasapi.example/soap", nil)
+	transport := NewHTTPDoError(req, errors.New("connection reset by peer"))
+	unavailable := NewUnexpectedStatusCodeError(req, http.StatusServiceUnavailable, nil)
+	flood := fmt.Errorf("add record: %w", &Fault{Code: "SOAP-ENV:Server", Message: "flood_protection"})
+	invalid := &Fault{Code: "SOAP-ENV:Server", Message: "record_data_syntax_incorrect"}
+
+	testCases := []struct {
+		action string
+		err    error
+		want   bool
+	}{
+		{action: "get_dns_settings", err: transport, want: true},
+		{action: "get_dns_settings", err: unavailable, want: true},
+		{action: "get_dns_settings", err: flood, want: true},
+		{action: "get_dns_settings", err: invalid},
+		{action: "get_dns_settings", err: context.Canceled},
+		{action: "update_dns_settings", err: transport, want: true},
+		{action: "delete_dns_settings", err: transport, want: true},
+		{action: "add_dns_settings", err: transport},
+		{action: "add_dns_settings", err: unavailable},
+		{action: "add_dns_settings", err: flood, want: true},
+		{action: "add_mailaccount", err: transport},
+		{action: "add_mailforward", err: transport},
+		{action: "add_mailforward", err: invalid},
+	}
+
+	for _, testCase := range testCases {
+		if got := retryable(testCase.action, testCase.err); got != testCase.want {
+			t.Errorf("retryable(%q, %v) = %t, want %t", testCase.action, testCase.err, got, testCase.want)
+		}
+	}
+}
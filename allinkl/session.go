@@ -0,0 +1,104 @@
+package allinkl
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// sessionLifetime is the lifetime in seconds of the KAS sessions the client
+// requests. KAS extends it with every request made in the session.
+const sessionLifetime = 300
+
+// sessionRenewMargin is how long before its expiry a session is replaced
+// instead of reused, so it does not expire mid-request.
+const sessionRenewMargin = 30 * time.Second
+
+// session caches the KAS session token of a client, so a run of many
+// requests authenticates once instead of before every request.
+type session struct {
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// withSession runs fn with a context carrying a session token. A cached
+// session rejected by KAS, e.g. because it was ended elsewhere, is replaced
+// and fn run once more.
+func (c *Client) withSession(ctx context.Context, fn func(ctx context.Context) error) error {
+	if getToken(ctx) != "" {
+		return fn(ctx)
+	}
+
+	token, cached, err := c.sessionToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = fn(WithContext(ctx, token))
+	if cached && isSessionFault(err) {
+		c.session.invalidate(token)
+		if token, _, err = c.sessionToken(ctx); err != nil {
+			return err
+		}
+		err = fn(WithContext(ctx, token))
+	}
+	if err == nil || !isSessionFault(err) {
+		c.session.touch(token)
+	}
+	return err
+}
+
+// sessionToken returns the cached session token, or authenticates for a new
+// one. cached reports whether the token was reused.
+func (c *Client) sessionToken(ctx context.Context) (token string, cached bool, err error) {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+
+	if c.session.token != "" && time.Now().Before(c.session.expires) {
+		return c.session.token, true, nil
+	}
+
+	token, err = c.identifier.Authentication(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	c.session.token = token
+	c.session.expires = time.Now().Add(sessionLifetime*time.Second - sessionRenewMargin)
+	return token, false, nil
+}
+
+// touch extends the cached session after a request made in it.
+func (s *session) touch(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.expires = time.Now().Add(sessionLifetime*time.Second - sessionRenewMargin)
+	}
+}
+
+// invalidate drops the cached session if it still is token.
+func (s *session) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// isSessionFault reports whether err is a SOAP fault rejecting the session
+// token of a request.
+func isSessionFault(err error) bool {
+	var fault *Fault
+	if !errors.As(err, &fault) {
+		return false
+	}
+	switch fault.Message {
+	case "kas_auth_data_incorrect", "session_timeout", "session_lifetime_expired":
+		return true
+	}
+	return false
+}
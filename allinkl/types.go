@@ -3,8 +3,10 @@ package allinkl
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 )
 
 const kasAPIEnvelope = `
@@ -59,6 +61,8 @@ type DNSRequest struct {
 // ---
 
 type GetDNSSettingsAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any                    `json:"Request" mapstructure:"Request"`
 	Response GetDNSSettingsResponse `json:"Response" mapstructure:"Response"`
 }
 
@@ -79,6 +83,8 @@ type ReturnInfo struct {
 }
 
 type AddDNSSettingsAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any                    `json:"Request" mapstructure:"Request"`
 	Response AddDNSSettingsResponse `json:"Response" mapstructure:"Response"`
 }
 
@@ -89,6 +95,8 @@ type AddDNSSettingsResponse struct {
 }
 
 type DeleteDNSSettingsAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any                       `json:"Request" mapstructure:"Request"`
 	Response DeleteDNSSettingsResponse `json:"Response"`
 }
 
@@ -98,6 +106,60 @@ type DeleteDNSSettingsResponse struct {
 	ReturnString  string  `json:"ReturnString"`
 }
 
+type GetDomainsAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any                `json:"Request" mapstructure:"Request"`
+	Response GetDomainsResponse `json:"Response" mapstructure:"Response"`
+}
+
+type GetDomainsResponse struct {
+	KasFloodDelay float64  `json:"KasFloodDelay" mapstructure:"KasFloodDelay"`
+	ReturnInfo    []Domain `json:"ReturnInfo" mapstructure:"ReturnInfo"`
+	ReturnString  string   `json:"ReturnString" mapstructure:"ReturnString"`
+}
+
+// Domain a domain of the account.
+type Domain struct {
+	Name string `json:"domain_name" mapstructure:"domain_name"`
+	Path string `json:"domain_path,omitempty" mapstructure:"domain_path"`
+	// Other holds the domain settings the client does not use, e.g. the
+	// redirect and PHP settings.
+	Other map[string]any `json:"-" mapstructure:",remain"`
+}
+
+type GetSubdomainsAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any                   `json:"Request" mapstructure:"Request"`
+	Response GetSubdomainsResponse `json:"Response" mapstructure:"Response"`
+}
+
+type GetSubdomainsResponse struct {
+	KasFloodDelay float64     `json:"KasFloodDelay" mapstructure:"KasFloodDelay"`
+	ReturnInfo    []Subdomain `json:"ReturnInfo" mapstructure:"ReturnInfo"`
+	ReturnString  string      `json:"ReturnString" mapstructure:"ReturnString"`
+}
+
+// Subdomain a subdomain of the account. Its DNS records belong to the zone
+// of its domain.
+type Subdomain struct {
+	Name string `json:"subdomain_name" mapstructure:"subdomain_name"`
+	Path string `json:"subdomain_path,omitempty" mapstructure:"subdomain_path"`
+	// Other holds the subdomain settings the client does not use.
+	Other map[string]any `json:"-" mapstructure:",remain"`
+}
+
+type GetAccountResourcesAPIResponse struct {
+	// Request echoes the request KAS processed.
+	Request  any                         `json:"Request" mapstructure:"Request"`
+	Response GetAccountResourcesResponse `json:"Response" mapstructure:"Response"`
+}
+
+type GetAccountResourcesResponse struct {
+	KasFloodDelay float64        `json:"KasFloodDelay" mapstructure:"KasFloodDelay"`
+	ReturnInfo    map[string]any `json:"ReturnInfo" mapstructure:"ReturnInfo"`
+	ReturnString  string         `json:"ReturnString" mapstructure:"ReturnString"`
+}
+
 // helper
 
 // Trimmer trim all XML fields.
@@ -118,12 +180,52 @@ type Fault struct {
 	Code    string `xml:"faultcode"`
 	Message string `xml:"faultstring"`
 	Actor   string `xml:"faultactor"`
+
+	// Action is the KAS action of the failed request, e.g.
+	// add_dns_settings. It is empty for authentication faults.
+	Action string `xml:"-"`
 }
 
 func (f Fault) Error() string {
 	return fmt.Sprintf("%s: %s: %s", f.Actor, f.Code, f.Message)
 }
 
+// IsNotFound reports whether err is a SOAP fault signaling that the requested
+// object (e.g. record_id_not_found, zone_not_found) does not exist.
+func IsNotFound(err error) bool {
+	var fault *Fault
+	if !errors.As(err, &fault) {
+		return false
+	}
+	return strings.HasSuffix(fault.Message, "_not_found")
+}
+
+// IsAlreadyExists reports whether err is a SOAP fault signaling that an
+// identical object (e.g. record_already_exists) already exists.
+func IsAlreadyExists(err error) bool {
+	var fault *Fault
+	if !errors.As(err, &fault) {
+		return false
+	}
+	return strings.HasSuffix(fault.Message, "_already_exists")
+}
+
+// IsPermissionDenied reports whether err is a SOAP fault signaling that the
+// KAS login is not allowed to use the action of the request, e.g. because the
+// action is disabled for a sub-account. The action is available as
+// Fault.Action.
+func IsPermissionDenied(err error) bool {
+	var fault *Fault
+	if !errors.As(err, &fault) {
+		return false
+	}
+	switch fault.Message {
+	case "kas_action_not_allowed", "api_function_not_allowed", "no_permission", "permission_denied", "action_not_allowed":
+		return true
+	}
+	return strings.HasSuffix(fault.Message, "_not_allowed") || strings.HasSuffix(fault.Message, "_permission_denied")
+}
+
 // KasResponse a KAS SOAP response.
 type KasResponse struct {
 	Return *Item `xml:"return"`
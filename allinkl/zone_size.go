@@ -0,0 +1,69 @@
+package allinkl
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timeouts of get_dns_settings requests, scaled with the number of records
+// the zone returned previously.
+const (
+	zoneReadBaseTimeout      = 10 * time.Second
+	zoneReadPerRecordTimeout = 100 * time.Millisecond
+	zoneReadMaxTimeout       = 5 * time.Minute
+)
+
+type requestTimeoutKey string
+
+const requestTimeoutContextKey requestTimeoutKey = "request_timeout"
+
+// zoneSizes remembers the number of records last returned per zone.
+type zoneSizes struct {
+	mu    sync.Mutex
+	sizes map[string]int
+}
+
+func (z *zoneSizes) observe(zone string, records int) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if z.sizes == nil {
+		z.sizes = map[string]int{}
+	}
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	if records > z.sizes[zone] {
+		z.sizes[zone] = records
+	}
+}
+
+// readTimeout returns the timeout of a get_dns_settings request for zone,
+// or defaultRequestTimeout if the size of the zone is not known yet.
+func (z *zoneSizes) readTimeout(zone string) time.Duration {
+	z.mu.Lock()
+	records, ok := z.sizes[strings.ToLower(strings.TrimSuffix(zone, "."))]
+	z.mu.Unlock()
+	if !ok {
+		return defaultRequestTimeout
+	}
+
+	timeout := zoneReadBaseTimeout + time.Duration(records)*zoneReadPerRecordTimeout
+	if timeout > zoneReadMaxTimeout {
+		timeout = zoneReadMaxTimeout
+	}
+	return timeout
+}
+
+// withRequestTimeout returns a context overriding defaultRequestTimeout for
+// requests made with it.
+func withRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutContextKey, timeout)
+}
+
+func getRequestTimeout(ctx context.Context) time.Duration {
+	timeout, ok := ctx.Value(requestTimeoutContextKey).(time.Duration)
+	if !ok {
+		return defaultRequestTimeout
+	}
+	return timeout
+}
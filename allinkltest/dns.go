@@ -0,0 +1,249 @@
+package allinkltest
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// dnsTTL is the TTL the nameserver of a Server serves every record with.
+const dnsTTL = 3600
+
+// dnsTypes maps the query types the nameserver of a Server answers to KAS
+// record types.
+var dnsTypes = map[dnsmessage.Type]string{
+	dnsmessage.TypeA:     "A",
+	dnsmessage.TypeAAAA:  "AAAA",
+	dnsmessage.TypeCNAME: "CNAME",
+	dnsmessage.TypeMX:    "MX",
+	dnsmessage.TypeNS:    "NS",
+	dnsmessage.TypeTXT:   "TXT",
+}
+
+// DNSAddress starts a nameserver serving the zones of the server over UDP,
+// unless it is running already, and returns its address, e.g.
+// 127.0.0.1:40053, for use wherever the provider takes nameservers.
+func (s *Server) DNSAddress() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dns == nil {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			panic(fmt.Sprintf("allinkltest: failed to start nameserver: %v", err))
+		}
+		s.dns = conn
+		go s.serveDNS(conn)
+	}
+	return s.dns.LocalAddr().String()
+}
+
+// SetDNSLag makes the nameserver keep serving the zones as they are now,
+// like a nameserver changes did not reach yet, until called with false.
+func (s *Server) SetDNSLag(lag bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dnsZones, s.dnsSerials = nil, nil
+	if !lag {
+		return
+	}
+	s.dnsZones = make(map[string][]Record, len(s.zones))
+	for zone, records := range s.zones {
+		s.dnsZones[zone] = append([]Record(nil), records...)
+	}
+	s.dnsSerials = make(map[string]uint32, len(s.serials))
+	for zone, serial := range s.serials {
+		s.dnsSerials[zone] = serial
+	}
+}
+
+// Serial returns the SOA serial of zone, which counts the changes to its
+// records.
+func (s *Server) Serial(zone string) uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.serials[zoneKey(zone)]
+}
+
+func (s *Server) serveDNS(conn net.PacketConn) {
+	buf := make([]byte, 4096)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var query dnsmessage.Message
+		if err := query.Unpack(buf[:n]); err != nil || query.Response {
+			continue
+		}
+		response := s.answerDNS(query)
+		packed, err := response.Pack()
+		if err != nil {
+			continue
+		}
+		_, _ = conn.WriteTo(packed, addr)
+	}
+}
+
+// answerDNS answers query authoritatively from the zones of the server,
+// refusing names outside of them.
+func (s *Server) answerDNS(query dnsmessage.Message) dnsmessage.Message {
+	response := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:               query.ID,
+			Response:         true,
+			OpCode:           query.OpCode,
+			RecursionDesired: query.RecursionDesired,
+		},
+		Questions: query.Questions,
+	}
+	if len(query.Questions) != 1 {
+		response.RCode = dnsmessage.RCodeFormatError
+		return response
+	}
+	question := query.Questions[0]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zones, serials := s.zones, s.serials
+	if s.dnsZones != nil {
+		zones, serials = s.dnsZones, s.dnsSerials
+	}
+
+	name := strings.ToLower(question.Name.String())
+	zone := ""
+	for candidate := range zones {
+		if (name == candidate || strings.HasSuffix(name, "."+candidate)) && len(candidate) > len(zone) {
+			zone = candidate
+		}
+	}
+	if zone == "" {
+		response.RCode = dnsmessage.RCodeRefused
+		return response
+	}
+	response.Authoritative = true
+
+	header := dnsmessage.ResourceHeader{Name: question.Name, Type: question.Type, Class: dnsmessage.ClassINET, TTL: dnsTTL}
+	exists := name == zone
+	if exists && question.Type == dnsmessage.TypeSOA {
+		response.Answers = append(response.Answers, dnsmessage.Resource{
+			Header: header,
+			Body: &dnsmessage.SOAResource{
+				NS:      dnsmessage.MustNewName("ns5.kasserver.com."),
+				MBox:    dnsmessage.MustNewName("hostmaster.kasserver.com."),
+				Serial:  serials[zone],
+				Refresh: 7200,
+				Retry:   1800,
+				Expire:  1209600,
+				MinTTL:  dnsTTL,
+			},
+		})
+	}
+	for _, record := range zones[zone] {
+		if s.deleted[record.ID] || recordFQDN(record) != name {
+			continue
+		}
+		exists = true
+		if record.Type != dnsTypes[question.Type] {
+			continue
+		}
+		body, err := recordBody(record)
+		if err != nil {
+			continue
+		}
+		response.Answers = append(response.Answers, dnsmessage.Resource{Header: header, Body: body})
+	}
+	if !exists {
+		response.RCode = dnsmessage.RCodeNameError
+	}
+	return response
+}
+
+// recordFQDN returns the lower case name of record with a trailing dot.
+func recordFQDN(record Record) string {
+	if record.Name == "" || record.Name == "@" {
+		return record.Zone
+	}
+	return strings.ToLower(record.Name) + "." + record.Zone
+}
+
+// recordBody returns the DNS resource of record.
+func recordBody(record Record) (dnsmessage.ResourceBody, error) {
+	switch record.Type {
+	case "A":
+		ip := net.ParseIP(record.Data).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q", record.Data)
+		}
+		var body dnsmessage.AResource
+		copy(body.A[:], ip)
+		return &body, nil
+	case "AAAA":
+		ip := net.ParseIP(record.Data)
+		if ip == nil || ip.To4() != nil {
+			return nil, fmt.Errorf("invalid IPv6 address %q", record.Data)
+		}
+		var body dnsmessage.AAAAResource
+		copy(body.AAAA[:], ip)
+		return &body, nil
+	case "CNAME", "MX", "NS":
+		name, err := dnsmessage.NewName(strings.TrimSuffix(record.Data, ".") + ".")
+		if err != nil {
+			return nil, err
+		}
+		switch record.Type {
+		case "CNAME":
+			return &dnsmessage.CNAMEResource{CNAME: name}, nil
+		case "MX":
+			return &dnsmessage.MXResource{Pref: uint16(record.Aux), MX: name}, nil
+		default:
+			return &dnsmessage.NSResource{NS: name}, nil
+		}
+	case "TXT":
+		return &dnsmessage.TXTResource{TXT: txtStrings(record.Data)}, nil
+	default:
+		return nil, fmt.Errorf("record type %s is not served", record.Type)
+	}
+}
+
+// txtStrings splits TXT record data into its character strings. Data of
+// quoted strings like `"v=spf1" " -all"` is unquoted, other data is served
+// in chunks of 255 bytes.
+func txtStrings(data string) []string {
+	if !strings.HasPrefix(data, `"`) {
+		var chunks []string
+		for len(data) > 255 {
+			chunks = append(chunks, data[:255])
+			data = data[255:]
+		}
+		return append(chunks, data)
+	}
+
+	var chunks []string
+	var chunk strings.Builder
+	quoted, escaped := false, false
+	for _, r := range data {
+		switch {
+		case escaped:
+			chunk.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			if quoted {
+				chunks = append(chunks, chunk.String())
+				chunk.Reset()
+			}
+			quoted = !quoted
+		case quoted:
+			chunk.WriteRune(r)
+		}
+	}
+	return chunks
+}
@@ -0,0 +1,26 @@
+// Package allinkltest provides an in-memory mock of the KAS API and
+// provider factories backed by it, so Go tests of modules and tools built
+// on this provider run without All-Inkl credentials.
+//
+// A typical test starts a Server, seeds the zones it needs and passes
+// ProtoV6ProviderFactories together with Server.ProviderConfig to
+// terraform-plugin-testing:
+//
+//	server := allinkltest.NewServer("login", "password")
+//	defer server.Close()
+//	server.AddZone("example.com")
+//
+//	resource.Test(t, resource.TestCase{
+//		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+//		Steps: []resource.TestStep{{
+//			Config: server.ProviderConfig() + `resource "allinkl_dns" "www" { ... }`,
+//		}},
+//	})
+//
+// Server.DNSAddress starts a nameserver serving the zones of the Server, for
+// attributes like wait_for_propagation that query nameservers.
+//
+// For `terraform test` runs, start a Server from a small Go program and
+// point the provider at it with the ALLINKL_API_ENDPOINT and
+// ALLINKL_AUTH_ENDPOINT environment variables (see Server.Env).
+package allinkltest
@@ -0,0 +1,41 @@
+package allinkltest
+
+import (
+	"fmt"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// ProtoV6ProviderFactories returns provider factories serving the allinkl
+// provider, for use as ProtoV6ProviderFactories of terraform-plugin-testing.
+func ProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"allinkl": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+// ProviderConfig returns a provider block configuring the allinkl provider
+// to use s.
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "allinkl" {
+  username      = %q
+  password      = %q
+  api_endpoint  = %q
+  auth_endpoint = %q
+}
+`, s.login, s.password, s.APIEndpoint(), s.AuthEndpoint())
+}
+
+// Env returns the environment variables configuring the allinkl provider
+// to use s.
+func (s *Server) Env() map[string]string {
+	return map[string]string{
+		"ALLINKL_USERNAME":      s.login,
+		"ALLINKL_PASSWORD":      s.password,
+		"ALLINKL_API_ENDPOINT":  s.APIEndpoint(),
+		"ALLINKL_AUTH_ENDPOINT": s.AuthEndpoint(),
+	}
+}
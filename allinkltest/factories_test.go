@@ -0,0 +1,65 @@
+package allinkltest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestProviderFactoriesDNSRecord(t *testing.T) {
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.AddZone("example.com")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_dns_record" "www" {
+  zone_host   = "example.com"
+  record_type = "A"
+  record_name = "www"
+  record_data = "192.0.2.1"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("allinkl_dns_record.www", "id"),
+					resource.TestCheckResourceAttr("allinkl_dns_record.www", "fqdn", "www.example.com"),
+					func(*terraform.State) error {
+						return server.checkRecords("example.com", "A www 192.0.2.1")
+					},
+				),
+			},
+			{
+				ResourceName:      "allinkl_dns_record.www",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					return "example.com/" + state.RootModule().Resources["allinkl_dns_record.www"].Primary.ID, nil
+				},
+				ImportStateVerifyIgnore: []string{"last_updated", "allow_adopt", "create_only", "wait_for_delete", "allow_delegation_change", "check_zone_soa"},
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			return server.checkRecords("example.com")
+		},
+	})
+}
+
+// checkRecords compares the changeable records of zone, formatted as
+// "<type> <name> <data>", with want.
+func (s *Server) checkRecords(zone string, want ...string) error {
+	var got []string
+	for _, record := range s.Records(zone) {
+		if record.Changeable {
+			got = append(got, fmt.Sprintf("%s %s %s", record.Type, record.Name, record.Data))
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		return fmt.Errorf("records of %s = %q, want %q", zone, got, want)
+	}
+	return nil
+}
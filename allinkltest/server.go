@@ -0,0 +1,765 @@
+package allinkltest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	apiPath  = "/soap/KasApi.php"
+	authPath = "/soap/KasAuth.php"
+
+	// sessionToken prefixes the session tokens handed out by the mock
+	// server.
+	sessionToken = "allinkltest-session-token"
+)
+
+// Record is a DNS record held by a Server.
+type Record struct {
+	ID         string
+	Zone       string
+	Name       string
+	Type       string
+	Data       string
+	Aux        int
+	Changeable bool
+}
+
+// DefaultMailQuota is the mailbox size in MB a Server assigns to mail
+// accounts created without a quota, as KAS assigns the default of the
+// package.
+const DefaultMailQuota = 1024
+
+// MailAccount is a mail account held by a Server.
+type MailAccount struct {
+	Login    string
+	Address  string
+	Password string
+	Quota    int
+}
+
+// MailForward is a mail forward held by a Server.
+type MailForward struct {
+	Address string
+	Targets []string
+}
+
+// Server is an in-memory mock of the KAS API and authentication endpoints
+// supporting the DNS and mail account actions used by the provider.
+type Server struct {
+	login    string
+	password string
+	server   *httptest.Server
+
+	mu         sync.Mutex
+	zones      map[string][]Record
+	subdomains []string
+	resources  map[string][2]int
+	mail       []MailAccount
+	forwards   []MailForward
+	nextID     int
+	faults     map[string]string
+
+	// keepDeleted keeps deleted records listed until cleared; deleted
+	// holds their IDs.
+	keepDeleted bool
+	deleted     map[string]bool
+
+	// serials holds the SOA serial of every zone, counting its changes.
+	serials map[string]uint32
+
+	// dns is the connection of the nameserver started by DNSAddress;
+	// dnsZones and dnsSerials hold the zones it serves while lagging.
+	dns        net.PacketConn
+	dnsZones   map[string][]Record
+	dnsSerials map[string]uint32
+
+	// sessions counts the sessions handed out; only the latest is valid.
+	sessions int
+
+	// ExtraResponseFields are added to the Response of every API call,
+	// e.g. to simulate fields introduced by a newer KAS version.
+	ExtraResponseFields map[string]any
+}
+
+// NewServer starts a mock KAS server accepting the given login and password.
+// Callers must Close it when done.
+func NewServer(login, password string) *Server {
+	s := &Server{
+		login:    login,
+		password: password,
+		zones:    map[string][]Record{},
+		serials:  map[string]uint32{},
+		nextID:   1000,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPath, s.handleAPI)
+	mux.HandleFunc(authPath, s.handleAuth)
+	s.server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dns != nil {
+		_ = s.dns.Close()
+	}
+}
+
+// APIEndpoint returns the URL of the mock KAS API.
+func (s *Server) APIEndpoint() string {
+	return s.server.URL + apiPath
+}
+
+// AuthEndpoint returns the URL of the mock KAS authentication API.
+func (s *Server) AuthEndpoint() string {
+	return s.server.URL + authPath
+}
+
+// AddZone adds an empty zone with the non-changeable NS records KAS creates
+// for every zone.
+func (s *Server) AddZone(zone string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := zoneKey(zone)
+	if _, ok := s.zones[key]; ok {
+		return
+	}
+	s.zones[key] = nil
+	for _, ns := range []string{"ns5.kasserver.com.", "ns6.kasserver.com."} {
+		s.addRecordLocked(Record{Zone: key, Type: "NS", Data: ns})
+	}
+}
+
+// AddRecord adds a record to the zone of record, creating the zone if
+// needed, and returns its ID. An empty ID is assigned by the server. Set
+// Changeable for records the provider may update or delete.
+func (s *Server) AddRecord(record Record) string {
+	s.AddZone(record.Zone)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addRecordLocked(record)
+}
+
+// AddSubdomain adds a subdomain, as listed by get_subdomains.
+func (s *Server) AddSubdomain(subdomain string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subdomains = append(s.subdomains, strings.TrimSuffix(strings.ToLower(subdomain), "."))
+}
+
+// SetAccountResource sets how many resources of the given name, e.g.
+// database, the account package includes and how many are in use, as
+// listed by get_accountresources.
+func (s *Server) SetAccountResource(name string, max, used int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.resources == nil {
+		s.resources = map[string][2]int{}
+	}
+	s.resources[name] = [2]int{max, used}
+}
+
+// MailAccounts returns the mail accounts of the server.
+func (s *Server) MailAccounts() []MailAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]MailAccount(nil), s.mail...)
+}
+
+// MailForwards returns the mail forwards of the server.
+func (s *Server) MailForwards() []MailForward {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]MailForward(nil), s.forwards...)
+}
+
+// SetFault makes every call of action fail with fault, e.g.
+// SetFault("add_dns_settings", "record_data_syntax_incorrect"). An empty
+// fault lets action succeed again.
+func (s *Server) SetFault(action, fault string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.faults == nil {
+		s.faults = map[string]string{}
+	}
+	s.faults[action] = fault
+}
+
+// KeepDeleted makes deleted records stay listed, as KAS lists records for a
+// while after deleting them. Calling it with false removes them.
+func (s *Server) KeepDeleted(keep bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keepDeleted = keep
+	if keep {
+		return
+	}
+	for id := range s.deleted {
+		if zone, i, ok := s.findLocked(id); ok {
+			s.zones[zone] = append(s.zones[zone][:i], s.zones[zone][i+1:]...)
+		}
+	}
+	s.deleted = nil
+}
+
+// Records returns the records of zone.
+func (s *Server) Records(zone string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Record(nil), s.zones[zoneKey(zone)]...)
+}
+
+func (s *Server) addRecordLocked(record Record) string {
+	if record.ID == "" {
+		s.nextID++
+		record.ID = strconv.Itoa(s.nextID)
+	}
+	record.Zone = zoneKey(record.Zone)
+	s.zones[record.Zone] = append(s.zones[record.Zone], record)
+	s.serials[record.Zone]++
+	return record.ID
+}
+
+// findLocked returns the zone and index of the record with the given ID.
+func (s *Server) findLocked(id string) (string, int, bool) {
+	for zone, records := range s.zones {
+		for i, record := range records {
+			if record.ID == id {
+				return zone, i, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Login    string `json:"kas_login"`
+		AuthData string `json:"kas_auth_data"`
+	}
+	if err := readParams(r, &params); err != nil {
+		writeFault(w, "SOAP-ENV:Client", err.Error())
+		return
+	}
+
+	if params.Login != s.login || params.AuthData != s.password {
+		writeFault(w, "SOAP-ENV:Server", "kas_password_incorrect")
+		return
+	}
+
+	s.mu.Lock()
+	s.sessions++
+	token := s.sessionTokenLocked()
+	s.mu.Unlock()
+
+	writeEnvelope(w, "KasAuthResponse", `<return xsi:type="xsd:string">`+token+`</return>`)
+}
+
+// Sessions returns the number of sessions clients authenticated for.
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sessions
+}
+
+// EndSession invalidates the current session, as KAS does when it times out.
+func (s *Server) EndSession() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions++
+}
+
+func (s *Server) sessionTokenLocked() string {
+	return sessionToken + "-" + strconv.Itoa(s.sessions)
+}
+
+func (s *Server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Login         string         `json:"kas_login"`
+		AuthData      string         `json:"kas_auth_data"`
+		Action        string         `json:"kas_action"`
+		RequestParams map[string]any `json:"KasRequestParams"`
+	}
+	if err := readParams(r, &params); err != nil {
+		writeFault(w, "SOAP-ENV:Client", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if params.Login != s.login || params.AuthData != s.sessionTokenLocked() {
+		writeFault(w, "SOAP-ENV:Server", "kas_auth_data_incorrect")
+		return
+	}
+
+	if fault := s.faults[params.Action]; fault != "" {
+		writeFault(w, "SOAP-ENV:Server", fault)
+		return
+	}
+
+	var returnInfo any
+	var fault string
+	switch params.Action {
+	case "get_dns_settings":
+		returnInfo, fault = s.getDNSSettings(params.RequestParams)
+	case "add_dns_settings":
+		returnInfo, fault = s.addDNSSettings(params.RequestParams)
+	case "update_dns_settings":
+		returnInfo, fault = s.updateDNSSettings(params.RequestParams)
+	case "delete_dns_settings":
+		returnInfo, fault = s.deleteDNSSettings(params.RequestParams)
+	case "get_domains":
+		returnInfo = s.getDomains()
+	case "get_subdomains":
+		returnInfo = s.getSubdomains()
+	case "get_accountresources":
+		returnInfo = s.getAccountResources()
+	case "get_mailaccounts":
+		returnInfo, fault = s.getMailAccounts(params.RequestParams)
+	case "add_mailaccount":
+		returnInfo, fault = s.addMailAccount(params.RequestParams)
+	case "update_mailaccount":
+		returnInfo, fault = s.updateMailAccount(params.RequestParams)
+	case "delete_mailaccount":
+		returnInfo, fault = s.deleteMailAccount(params.RequestParams)
+	case "get_mailforwards":
+		returnInfo, fault = s.getMailForwards(params.RequestParams)
+	case "add_mailforward":
+		returnInfo, fault = s.addMailForward(params.RequestParams)
+	case "update_mailforward":
+		returnInfo, fault = s.updateMailForward(params.RequestParams)
+	case "delete_mailforward":
+		returnInfo, fault = s.deleteMailForward(params.RequestParams)
+	default:
+		fault = "kas_action_not_found"
+	}
+	if fault != "" {
+		writeFault(w, "SOAP-ENV:Server", fault)
+		return
+	}
+
+	fields := map[string]any{
+		"KasFloodDelay": 0.0,
+		"ReturnInfo":    returnInfo,
+		"ReturnString":  "TRUE",
+	}
+	for key, value := range s.ExtraResponseFields {
+		fields[key] = value
+	}
+
+	response := map[string]any{
+		"Request": map[string]any{
+			"KasRequestType": params.Action,
+		},
+		"Response": fields,
+	}
+	writeEnvelope(w, "KasApiResponse", encodeItem("return", response))
+}
+
+func (s *Server) getDNSSettings(params map[string]any) (any, string) {
+	records, ok := s.zones[zoneKey(stringParam(params, "zone_host"))]
+	if !ok {
+		return nil, "zone_not_found"
+	}
+
+	id := stringParam(params, "record_id")
+	var result []any
+	for _, record := range records {
+		if id != "" && record.ID != id {
+			continue
+		}
+		changeable := "N"
+		if record.Changeable {
+			changeable = "Y"
+		}
+		result = append(result, map[string]any{
+			"record_id":         record.ID,
+			"record_zone":       record.Zone,
+			"record_name":       record.Name,
+			"record_type":       record.Type,
+			"record_data":       record.Data,
+			"record_aux":        int64(record.Aux),
+			"record_changeable": changeable,
+		})
+	}
+	if id != "" && len(result) == 0 {
+		return nil, "record_id_not_found"
+	}
+	return result, ""
+}
+
+func (s *Server) addDNSSettings(params map[string]any) (any, string) {
+	zone := zoneKey(stringParam(params, "zone_host"))
+	if _, ok := s.zones[zone]; !ok {
+		return nil, "zone_not_found"
+	}
+
+	return s.addRecordLocked(Record{
+		Zone:       zone,
+		Name:       stringParam(params, "record_name"),
+		Type:       stringParam(params, "record_type"),
+		Data:       stringParam(params, "record_data"),
+		Aux:        intParam(params, "record_aux"),
+		Changeable: true,
+	}), ""
+}
+
+func (s *Server) updateDNSSettings(params map[string]any) (any, string) {
+	id := stringParam(params, "record_id")
+	zone, i, ok := s.findLocked(id)
+	if !ok {
+		return nil, "record_id_not_found"
+	}
+
+	record := &s.zones[zone][i]
+	if !record.Changeable {
+		return nil, "record_not_changeable"
+	}
+	record.Name = stringParam(params, "record_name")
+	record.Type = stringParam(params, "record_type")
+	record.Data = stringParam(params, "record_data")
+	record.Aux = intParam(params, "record_aux")
+	s.serials[zone]++
+	return id, ""
+}
+
+func (s *Server) deleteDNSSettings(params map[string]any) (any, string) {
+	zone, i, ok := s.findLocked(stringParam(params, "record_id"))
+	if !ok {
+		return nil, "record_id_not_found"
+	}
+	if !s.zones[zone][i].Changeable {
+		return nil, "record_not_changeable"
+	}
+
+	s.serials[zone]++
+	if s.keepDeleted {
+		if s.deleted == nil {
+			s.deleted = map[string]bool{}
+		}
+		s.deleted[s.zones[zone][i].ID] = true
+		return true, ""
+	}
+	s.zones[zone] = append(s.zones[zone][:i], s.zones[zone][i+1:]...)
+	return true, ""
+}
+
+func (s *Server) getDomains() any {
+	zones := make([]string, 0, len(s.zones))
+	for zone := range s.zones {
+		zones = append(zones, strings.TrimSuffix(zone, "."))
+	}
+	sort.Strings(zones)
+
+	result := []any{}
+	for _, zone := range zones {
+		result = append(result, map[string]any{
+			"domain_name": zone,
+			"domain_path": "/" + zone + "/",
+		})
+	}
+	return result
+}
+
+func (s *Server) getSubdomains() any {
+	result := []any{}
+	for _, subdomain := range s.subdomains {
+		result = append(result, map[string]any{
+			"subdomain_name": subdomain,
+			"subdomain_path": "/" + subdomain + "/",
+		})
+	}
+	return result
+}
+
+func (s *Server) getAccountResources() any {
+	result := map[string]any{}
+	for name, counts := range s.resources {
+		result["max_"+name] = map[string]any{
+			"max":  int64(counts[0]),
+			"used": int64(counts[1]),
+		}
+	}
+	return result
+}
+
+func (s *Server) getMailAccounts(params map[string]any) (any, string) {
+	login := stringParam(params, "mail_login")
+	result := []any{}
+	for _, account := range s.mail {
+		if login != "" && account.Login != login {
+			continue
+		}
+		result = append(result, map[string]any{
+			"mail_login":    account.Login,
+			"mail_adresses": account.Address,
+			"mail_quota":    int64(account.Quota),
+		})
+	}
+	if login != "" && len(result) == 0 {
+		return nil, "mail_login_not_found"
+	}
+	return result, ""
+}
+
+func (s *Server) addMailAccount(params map[string]any) (any, string) {
+	domain := stringParam(params, "domain_part")
+	if _, ok := s.zones[zoneKey(domain)]; !ok {
+		return nil, "domain_part_not_found"
+	}
+	if stringParam(params, "mail_password") == "" {
+		return nil, "mail_password_syntax_incorrect"
+	}
+
+	address := strings.ToLower(stringParam(params, "local_part") + "@" + strings.TrimSuffix(domain, "."))
+	for _, account := range s.mail {
+		if account.Address == address {
+			return nil, "mail_account_already_exists"
+		}
+	}
+
+	s.nextID++
+	account := MailAccount{
+		Login:    fmt.Sprintf("m%07d", s.nextID),
+		Address:  address,
+		Password: stringParam(params, "mail_password"),
+		Quota:    intParam(params, "mail_quota"),
+	}
+	if account.Quota == 0 {
+		account.Quota = DefaultMailQuota
+	}
+	s.mail = append(s.mail, account)
+	return account.Login, ""
+}
+
+func (s *Server) updateMailAccount(params map[string]any) (any, string) {
+	for i := range s.mail {
+		account := &s.mail[i]
+		if account.Login != stringParam(params, "mail_login") {
+			continue
+		}
+		if password := stringParam(params, "mail_new_password"); password != "" {
+			account.Password = password
+		}
+		if _, ok := params["mail_quota"]; ok {
+			account.Quota = intParam(params, "mail_quota")
+		}
+		return true, ""
+	}
+	return nil, "mail_login_not_found"
+}
+
+func (s *Server) deleteMailAccount(params map[string]any) (any, string) {
+	for i, account := range s.mail {
+		if account.Login == stringParam(params, "mail_login") {
+			s.mail = append(s.mail[:i], s.mail[i+1:]...)
+			return true, ""
+		}
+	}
+	return nil, "mail_login_not_found"
+}
+
+func (s *Server) getMailForwards(params map[string]any) (any, string) {
+	address := strings.ToLower(stringParam(params, "mail_forward"))
+	result := []any{}
+	for _, forward := range s.forwards {
+		if address != "" && forward.Address != address {
+			continue
+		}
+		result = append(result, map[string]any{
+			"mail_forward_adress":  forward.Address,
+			"mail_forward_targets": strings.Join(forward.Targets, ","),
+		})
+	}
+	if address != "" && len(result) == 0 {
+		return nil, "mail_forward_not_found"
+	}
+	return result, ""
+}
+
+func (s *Server) addMailForward(params map[string]any) (any, string) {
+	domain := stringParam(params, "domain_part")
+	if _, ok := s.zones[zoneKey(domain)]; !ok {
+		return nil, "domain_part_not_found"
+	}
+	targets := targetParams(params)
+	if len(targets) == 0 {
+		return nil, "target_syntax_incorrect"
+	}
+
+	address := strings.ToLower(stringParam(params, "local_part") + "@" + strings.TrimSuffix(domain, "."))
+	for _, forward := range s.forwards {
+		if forward.Address == address {
+			return nil, "mail_forward_already_exists"
+		}
+	}
+
+	s.forwards = append(s.forwards, MailForward{Address: address, Targets: targets})
+	return address, ""
+}
+
+func (s *Server) updateMailForward(params map[string]any) (any, string) {
+	address := strings.ToLower(stringParam(params, "mail_forward"))
+	for i := range s.forwards {
+		if s.forwards[i].Address != address {
+			continue
+		}
+		targets := targetParams(params)
+		if len(targets) == 0 {
+			return nil, "target_syntax_incorrect"
+		}
+		s.forwards[i].Targets = targets
+		return true, ""
+	}
+	return nil, "mail_forward_not_found"
+}
+
+func (s *Server) deleteMailForward(params map[string]any) (any, string) {
+	address := strings.ToLower(stringParam(params, "mail_forward"))
+	for i, forward := range s.forwards {
+		if forward.Address == address {
+			s.forwards = append(s.forwards[:i], s.forwards[i+1:]...)
+			return true, ""
+		}
+	}
+	return nil, "mail_forward_not_found"
+}
+
+// targetParams returns the target_<n> parameters of a mail forward request
+// in order.
+func targetParams(params map[string]any) []string {
+	var targets []string
+	for i := 0; ; i++ {
+		target := stringParam(params, "target_"+strconv.Itoa(i))
+		if target == "" {
+			return targets
+		}
+		targets = append(targets, target)
+	}
+}
+
+// readParams decodes the JSON Params of a KAS SOAP request.
+func readParams(r *http.Request, params any) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
+	start := strings.Index(string(body), "<Params>")
+	end := strings.LastIndex(string(body), "</Params>")
+	if start < 0 || end < start {
+		return fmt.Errorf("request has no Params")
+	}
+
+	return json.Unmarshal(body[start+len("<Params>"):end], params)
+}
+
+func stringParam(params map[string]any, key string) string {
+	v, _ := params[key].(string)
+	return v
+}
+
+func intParam(params map[string]any, key string) int {
+	switch v := params[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	default:
+		return 0
+	}
+}
+
+// zoneKey returns the canonical form of a zone name: lower case with a
+// trailing dot, as KAS reports zones.
+func zoneKey(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, ".")) + "."
+}
+
+func writeEnvelope(w http.ResponseWriter, response, content string) {
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:SOAP-ENC="http://schemas.xmlsoap.org/soap/encoding/" xmlns:ns1="https://kasserver.com/" xmlns:ns2="http://xml.apache.org/xml-soap">
+<SOAP-ENV:Body><ns1:%s>%s</ns1:%s></SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`, response, content, response)
+}
+
+func writeFault(w http.ResponseWriter, code, message string) {
+	// The client only decodes faults of successful responses.
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/">
+<SOAP-ENV:Body><SOAP-ENV:Fault><faultcode>%s</faultcode><faultstring>%s</faultstring><faultactor>KasApi</faultactor></SOAP-ENV:Fault></SOAP-ENV:Body>
+</SOAP-ENV:Envelope>`, escape(code), escape(message))
+}
+
+// encodeItem encodes v as a SOAP-encoded element named tag, in the format
+// KAS uses for its responses.
+func encodeItem(tag string, v any) string {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		for _, key := range keys {
+			b.WriteString(`<item><key xsi:type="xsd:string">` + escape(key) + `</key>` + encodeItem("value", v[key]) + `</item>`)
+		}
+		return `<` + tag + ` xsi:type="ns2:Map">` + b.String() + `</` + tag + `>`
+	case []any:
+		var b strings.Builder
+		for _, item := range v {
+			b.WriteString(encodeItem("item", item))
+		}
+		return `<` + tag + ` SOAP-ENC:arrayType="xsd:anyType[` + strconv.Itoa(len(v)) + `]" xsi:type="SOAP-ENC:Array">` + b.String() + `</` + tag + `>`
+	case string:
+		return `<` + tag + ` xsi:type="xsd:string">` + escape(v) + `</` + tag + `>`
+	case int64:
+		return `<` + tag + ` xsi:type="xsd:int">` + strconv.FormatInt(v, 10) + `</` + tag + `>`
+	case float64:
+		return `<` + tag + ` xsi:type="xsd:float">` + strconv.FormatFloat(v, 'f', -1, 64) + `</` + tag + `>`
+	case bool:
+		return `<` + tag + ` xsi:type="xsd:boolean">` + strconv.FormatBool(v) + `</` + tag + `>`
+	default:
+		return `<` + tag + ` xsi:nil="true"/>`
+	}
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,326 @@
+package allinkltest
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+)
+
+func TestServerDNSSettings(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.AddZone("example.com")
+
+	ctx := context.Background()
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+
+	id, err := client.AddDNSSettings(ctx, allinkl.DNSRequest{
+		ZoneHost:   "example.com",
+		RecordType: "MX",
+		RecordName: "",
+		RecordData: "mail.example.com.",
+		RecordAux:  10,
+	})
+	if err != nil {
+		t.Fatalf("AddDNSSettings: unexpected error: %s", err)
+	}
+
+	records, err := client.GetDNSSettings(ctx, "example.com", id)
+	if err != nil {
+		t.Fatalf("GetDNSSettings: unexpected error: %s", err)
+	}
+	if len(records) != 1 || records[0].RecordData != "mail.example.com." || records[0].RecordAux != 10 || records[0].Changeable != "Y" {
+		t.Fatalf("GetDNSSettings: unexpected records %+v", records)
+	}
+
+	_, err = client.UpdateDNSSettings(ctx, allinkl.DNSRequest{
+		RecordId:   id,
+		ZoneHost:   "example.com",
+		RecordType: "MX",
+		RecordData: "mx.example.com.",
+		RecordAux:  20,
+	})
+	if err != nil {
+		t.Fatalf("UpdateDNSSettings: unexpected error: %s", err)
+	}
+
+	all, err := client.GetDNSSettings(ctx, "example.com", "")
+	if err != nil {
+		t.Fatalf("GetDNSSettings: unexpected error: %s", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("GetDNSSettings: expected 2 NS records and the MX record, got %+v", all)
+	}
+
+	result, err := client.DeleteDNSSettings(ctx, id)
+	if err != nil || !result.ReturnInfo {
+		t.Fatalf("DeleteDNSSettings: unexpected result %+v, error: %v", result, err)
+	}
+
+	_, err = client.GetDNSSettings(ctx, "example.com", id)
+	if !allinkl.IsNotFound(err) {
+		t.Fatalf("GetDNSSettings: expected not found error, got: %v", err)
+	}
+
+	_, err = client.GetDNSSettings(ctx, "missing.example", "")
+	if !allinkl.IsNotFound(err) {
+		t.Fatalf("GetDNSSettings: expected zone not found error, got: %v", err)
+	}
+}
+
+func TestServerDomains(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+
+	subdomains, err := client.GetSubdomains(ctx)
+	if err != nil {
+		t.Fatalf("GetSubdomains: unexpected error: %s", err)
+	}
+	if len(subdomains) != 0 {
+		t.Fatalf("GetSubdomains: expected no subdomains, got %+v", subdomains)
+	}
+
+	server.AddZone("example.com")
+	server.AddZone("example.org")
+	server.AddSubdomain("www.example.com")
+
+	domains, err := client.GetDomains(ctx)
+	if err != nil {
+		t.Fatalf("GetDomains: unexpected error: %s", err)
+	}
+	if len(domains) != 2 || domains[0].Name != "example.com" || domains[1].Name != "example.org" {
+		t.Fatalf("GetDomains: unexpected domains %+v", domains)
+	}
+
+	subdomains, err = client.GetSubdomains(ctx)
+	if err != nil {
+		t.Fatalf("GetSubdomains: unexpected error: %s", err)
+	}
+	if len(subdomains) != 1 || subdomains[0].Name != "www.example.com" {
+		t.Fatalf("GetSubdomains: unexpected subdomains %+v", subdomains)
+	}
+}
+
+func TestServerAccountResources(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.SetAccountResource("database", 5, 2)
+	server.SetAccountResource("mail_account", 0, 0)
+
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+	resources, err := client.GetAccountResources(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccountResources: unexpected error: %s", err)
+	}
+
+	want := []allinkl.AccountResource{
+		{Name: "database", Max: 5, Used: 2},
+		{Name: "mail_account", Max: 0, Used: 0},
+	}
+	if !reflect.DeepEqual(resources, want) {
+		t.Fatalf("GetAccountResources: got %+v, want %+v", resources, want)
+	}
+}
+
+func TestServerMailAccounts(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.AddZone("example.com")
+
+	ctx := context.Background()
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+
+	login, err := client.AddMailAccount(ctx, allinkl.MailAccountRequest{
+		Password:   "secret",
+		LocalPart:  "info",
+		DomainPart: "example.com",
+		Quota:      500,
+	})
+	if err != nil {
+		t.Fatalf("AddMailAccount: unexpected error: %s", err)
+	}
+	if login == "" {
+		t.Fatal("AddMailAccount: expected a login")
+	}
+
+	if err := client.UpdateMailAccount(ctx, allinkl.MailAccountRequest{Login: login, NewPassword: "changed", Quota: 1000}); err != nil {
+		t.Fatalf("UpdateMailAccount: unexpected error: %s", err)
+	}
+
+	accounts, err := client.GetMailAccounts(ctx, login)
+	if err != nil {
+		t.Fatalf("GetMailAccounts: unexpected error: %s", err)
+	}
+	if len(accounts) != 1 || accounts[0].Address() != "info@example.com" || accounts[0].QuotaMB() != 1000 {
+		t.Fatalf("GetMailAccounts: unexpected mail accounts %+v", accounts)
+	}
+	if password := server.MailAccounts()[0].Password; password != "changed" {
+		t.Fatalf("UpdateMailAccount: password is %q, want %q", password, "changed")
+	}
+
+	if err := client.DeleteMailAccount(ctx, login); err != nil {
+		t.Fatalf("DeleteMailAccount: unexpected error: %s", err)
+	}
+	if _, err := client.GetMailAccounts(ctx, login); !allinkl.IsNotFound(err) {
+		t.Fatalf("GetMailAccounts after delete: expected a not found error, got %v", err)
+	}
+}
+
+func TestServerMailForwards(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.AddZone("example.com")
+
+	ctx := context.Background()
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+
+	if err := client.AddMailForward(ctx, "sales", "example.com", []string{"alice@example.org", "bob@example.org"}); err != nil {
+		t.Fatalf("AddMailForward: unexpected error: %s", err)
+	}
+	if err := client.UpdateMailForward(ctx, "sales@example.com", []string{"carol@example.org"}); err != nil {
+		t.Fatalf("UpdateMailForward: unexpected error: %s", err)
+	}
+
+	forwards, err := client.GetMailForwards(ctx, "sales@example.com")
+	if err != nil {
+		t.Fatalf("GetMailForwards: unexpected error: %s", err)
+	}
+	if len(forwards) != 1 || !reflect.DeepEqual(forwards[0].TargetList(), []string{"carol@example.org"}) {
+		t.Fatalf("GetMailForwards: unexpected mail forwards %+v", forwards)
+	}
+
+	if err := client.DeleteMailForward(ctx, "sales@example.com"); err != nil {
+		t.Fatalf("DeleteMailForward: unexpected error: %s", err)
+	}
+	if _, err := client.GetMailForwards(ctx, "sales@example.com"); !allinkl.IsNotFound(err) {
+		t.Fatalf("GetMailForwards after delete: expected a not found error, got %v", err)
+	}
+}
+
+func TestServerRejectsWrongPassword(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.AddZone("example.com")
+
+	client := allinkl.NewClientWithEndpoints("login", "wrong", server.APIEndpoint(), server.AuthEndpoint())
+	if _, err := client.GetDNSSettings(context.Background(), "example.com", ""); err == nil {
+		t.Fatal("expected an authentication error")
+	}
+}
+
+func TestServerStrictDecoding(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.AddZone("example.com")
+	server.ExtraResponseFields = map[string]any{"KasNewField": "value"}
+
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+	if _, err := client.GetDNSSettings(context.Background(), "example.com", ""); err != nil {
+		t.Fatalf("expected unknown fields to be ignored, got: %s", err)
+	}
+
+	client.StrictDecoding = true
+	if _, err := client.GetDNSSettings(context.Background(), "example.com", ""); err == nil {
+		t.Fatal("expected strict decoding to reject unknown fields")
+	}
+}
+
+func TestServerSessionReuse(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.AddZone("example.com")
+
+	ctx := context.Background()
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetDNSSettings(ctx, "example.com", ""); err != nil {
+			t.Fatalf("GetDNSSettings: unexpected error: %s", err)
+		}
+	}
+	if sessions := server.Sessions(); sessions != 1 {
+		t.Fatalf("client authenticated %d times, want 1", sessions)
+	}
+
+	// A session ended by KAS is replaced transparently.
+	server.EndSession()
+	if _, err := client.GetDNSSettings(ctx, "example.com", ""); err != nil {
+		t.Fatalf("GetDNSSettings after session end: unexpected error: %s", err)
+	}
+	if sessions := server.Sessions(); sessions != 3 {
+		t.Fatalf("got %d sessions, want 3 after the ended session was replaced", sessions)
+	}
+}
+
+func TestServerDNS(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer("login", "password")
+	defer server.Close()
+	server.AddRecord(Record{Zone: "example.com", Name: "www", Type: "A", Data: "192.0.2.1", Changeable: true})
+	server.AddRecord(Record{Zone: "example.com", Name: "_acme-challenge", Type: "TXT", Data: `"token" "part"`, Changeable: true})
+
+	address := server.DNSAddress()
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+	ctx := context.Background()
+
+	ips, err := resolver.LookupIP(ctx, "ip4", "www.example.com.")
+	if err != nil || len(ips) != 1 || ips[0].String() != "192.0.2.1" {
+		t.Fatalf("LookupIP = %v, %v, want 192.0.2.1", ips, err)
+	}
+	txts, err := resolver.LookupTXT(ctx, "_acme-challenge.example.com.")
+	if err != nil || !reflect.DeepEqual(txts, []string{"tokenpart"}) {
+		t.Fatalf("LookupTXT = %q, %v, want tokenpart", txts, err)
+	}
+	nss, err := resolver.LookupNS(ctx, "example.com.")
+	if err != nil || len(nss) != 2 {
+		t.Fatalf("LookupNS = %v, %v, want the 2 KAS nameservers", nss, err)
+	}
+	if _, err := resolver.LookupIP(ctx, "ip4", "api.example.com."); err == nil {
+		t.Fatal("LookupIP of a missing name: expected an error")
+	}
+
+	// The zone serial counts the changes to its records: 2 NS, A and TXT.
+	if serial := server.Serial("example.com"); serial != 4 {
+		t.Fatalf("Serial = %d, want 4", serial)
+	}
+
+	// A lagging nameserver keeps serving the old records.
+	server.SetDNSLag(true)
+	server.AddRecord(Record{Zone: "example.com", Name: "api", Type: "A", Data: "192.0.2.2", Changeable: true})
+	if _, err := resolver.LookupIP(ctx, "ip4", "api.example.com."); err == nil {
+		t.Fatal("LookupIP while lagging: expected an error")
+	}
+	server.SetDNSLag(false)
+	if ips, err := resolver.LookupIP(ctx, "ip4", "api.example.com."); err != nil || len(ips) != 1 {
+		t.Fatalf("LookupIP after lag = %v, %v, want 192.0.2.2", ips, err)
+	}
+}
@@ -3,7 +3,7 @@
 // import (
 // 	"context"
 // 	"fmt"
-// 	"terraform-provider-allinkl/internal/allinkl"
+// 	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
 
 // 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 // 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Actions logged by logDNSChange.
+const (
+	dnsChangeCreate = "create"
+	dnsChangeUpdate = "update"
+	dnsChangeDelete = "delete"
+)
+
+// dnsChange describes a change to a single record for the audit log. OldData
+// is empty for creates, NewData for deletes.
+type dnsChange struct {
+	Action     string
+	RecordID   string
+	ZoneHost   string
+	RecordType string
+	RecordName string
+	OldData    string
+	NewData    string
+}
+
+// logDNSChange logs change at INFO level with a fixed set of fields, so
+// apply logs double as an audit trail of DNS changes.
+func logDNSChange(ctx context.Context, change dnsChange) {
+	fields := map[string]any{
+		"action":      change.Action,
+		"record_id":   change.RecordID,
+		"zone_host":   normalizeHostname(change.ZoneHost),
+		"fqdn":        recordFQDN(change.RecordName, change.ZoneHost),
+		"record_type": change.RecordType,
+	}
+	if change.Action != dnsChangeCreate {
+		fields["old_data"] = change.OldData
+	}
+	if change.Action != dnsChangeDelete {
+		fields["new_data"] = change.NewData
+	}
+
+	tflog.Info(ctx, "AllInkl DNS change: "+change.Action+" "+change.RecordType+" "+recordFQDN(change.RecordName, change.ZoneHost), fields)
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// caaTags lists the CAA property tags defined by RFC 8659.
+var caaTags = []string{"issue", "issuewild", "iodef"}
+
+// dnsCAAModel maps the structured CAA attributes.
+type dnsCAAModel struct {
+	Flags types.Int64  `tfsdk:"flags"`
+	Tag   types.String `tfsdk:"tag"`
+	Value types.String `tfsdk:"value"`
+}
+
+// caaSchemaAttribute returns the schema of the caa attribute.
+func caaSchemaAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Structured CAA record data, composed into `record_data` as `<flags> <tag> \"<value>\"`. " +
+			"Conflicts with `record_data`.",
+		Attributes: map[string]schema.Attribute{
+			"flags": schema.Int64Attribute{
+				Required: true,
+			},
+			"tag": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "One of `issue`, `issuewild` or `iodef`.",
+			},
+			"value": schema.StringAttribute{
+				Required: true,
+			},
+		},
+	}
+}
+
+// isKnown reports whether every CAA field is known.
+func (m *dnsCAAModel) isKnown() bool {
+	return !m.Flags.IsUnknown() && !m.Tag.IsUnknown() && !m.Value.IsUnknown()
+}
+
+// recordData composes the KAS record_data of the CAA record.
+func (m *dnsCAAModel) recordData() string {
+	return fmt.Sprintf("%d %s %s", m.Flags.ValueInt64(), m.Tag.ValueString(), quoteCharacterString(m.Value.ValueString()))
+}
+
+// validate checks the CAA flags and tag.
+func (m *dnsCAAModel) validate() error {
+	if !m.Flags.IsUnknown() && (m.Flags.ValueInt64() < 0 || m.Flags.ValueInt64() > 255) {
+		return fmt.Errorf("flags must be between 0 and 255, got %d", m.Flags.ValueInt64())
+	}
+	if !m.Tag.IsUnknown() {
+		for _, tag := range caaTags {
+			if m.Tag.ValueString() == tag {
+				return nil
+			}
+		}
+		return fmt.Errorf("tag must be one of %s, got %q", strings.Join(caaTags, ", "), m.Tag.ValueString())
+	}
+	return nil
+}
+
+// parseCAARecordData decomposes KAS CAA record_data `<flags> <tag> "<value>"`.
+// Flags and tag may be separated by any whitespace; the value is the rest of
+// the data.
+func parseCAARecordData(data string) (*dnsCAAModel, error) {
+	flagsField, rest := cutField(data)
+	tag, rest := cutField(rest)
+	value := strings.TrimSpace(rest)
+	if flagsField == "" || tag == "" || value == "" {
+		return nil, fmt.Errorf("expected `<flags> <tag> \"<value>\"`, got %q", data)
+	}
+
+	flags, err := strconv.ParseInt(flagsField, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flags %q: %w", flagsField, err)
+	}
+
+	if chunks, ok := parseCharacterStrings(value); ok && len(chunks) == 1 {
+		value = chunks[0]
+	}
+
+	caa := &dnsCAAModel{
+		Flags: types.Int64Value(flags),
+		Tag:   types.StringValue(tag),
+		Value: types.StringValue(value),
+	}
+	return caa, caa.validate()
+}
+
+// cutField returns the first whitespace separated field of s and the rest of
+// s after it.
+func cutField(s string) (field, rest string) {
+	s = strings.TrimLeft(s, " \t")
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// canonicalCAA returns CAA record data with single spaces between fields, the
+// tag in lower case, as tags match case-insensitively, and the value quoted.
+// Data that does not parse is returned unchanged.
+func canonicalCAA(data string) string {
+	caa, _ := parseCAARecordData(data)
+	if caa == nil {
+		return data
+	}
+	caa.Tag = types.StringValue(strings.ToLower(caa.Tag.ValueString()))
+	return caa.recordData()
+}
+
+// validateCAARecordData validates CAA record data given as a plain string.
+func validateCAARecordData(data string) error {
+	_, err := parseCAARecordData(data)
+	return err
+}
+
+// caaValue refreshes the structured CAA attributes from remote record data.
+// Records managed through record_data keep a null caa attribute.
+func caaValue(known *dnsCAAModel, remote string) *dnsCAAModel {
+	if known == nil {
+		return nil
+	}
+
+	caa, err := parseCAARecordData(remote)
+	if err != nil {
+		return known
+	}
+	return caa
+}
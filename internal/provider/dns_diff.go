@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+)
+
+// recordComparison formats the values of known records, usually from the
+// state, next to the remote records as a table for diagnostics:
+//
+//	             state      remote (ID 1234)
+//	record_type  A          CNAME
+//	record_name  www        www
+//	...
+func recordComparison(known []dnsZoneRecordModel, remote []allinkl.ReturnInfo) string {
+	header := []string{""}
+	rows := [][]string{{"record_type"}, {"record_name"}, {"record_data"}, {"record_aux"}}
+
+	for _, record := range known {
+		header = append(header, "state")
+		rows[0] = append(rows[0], comparisonValue(record.Type.ValueString(), record.Type.IsUnknown()))
+		rows[1] = append(rows[1], comparisonValue(record.Name.ValueString(), record.Name.IsUnknown()))
+		rows[2] = append(rows[2], comparisonValue(record.Data.ValueString(), record.Data.IsUnknown()))
+		aux := fmt.Sprint(record.Aux.ValueInt64())
+		if record.Aux.IsUnknown() {
+			aux = "(known after apply)"
+		}
+		rows[3] = append(rows[3], aux)
+	}
+	for _, record := range remote {
+		header = append(header, fmt.Sprintf("remote (ID %v)", record.ID))
+		rows[0] = append(rows[0], comparisonValue(record.RecordType, false))
+		rows[1] = append(rows[1], comparisonValue(record.RecordName, false))
+		rows[2] = append(rows[2], comparisonValue(record.RecordData, false))
+		rows[3] = append(rows[3], fmt.Sprint(record.RecordAux))
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, row := range append([][]string{header}, rows...) {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+// comparisonValue formats a value for recordComparison, quoting it so empty
+// values and surrounding whitespace are visible.
+func comparisonValue(value string, unknown bool) string {
+	if unknown {
+		return "(known after apply)"
+	}
+	return fmt.Sprintf("%q", value)
+}
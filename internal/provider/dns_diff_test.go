@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRecordComparison(t *testing.T) {
+	t.Parallel()
+
+	known := []dnsZoneRecordModel{{
+		Type: types.StringValue("A"),
+		Name: types.StringValue("www"),
+		Data: types.StringValue("192.0.2.1"),
+		Aux:  types.Int64Value(0),
+	}}
+	remote := []allinkl.ReturnInfo{
+		{ID: "1234", RecordType: "CNAME", RecordName: "www", RecordData: "target.example.com"},
+		{ID: "1235", RecordType: "A", RecordName: "", RecordData: "192.0.2.2"},
+	}
+
+	got := recordComparison(known, remote)
+	want := `             state        remote (ID 1234)      remote (ID 1235)
+record_type  "A"          "CNAME"               "A"
+record_name  "www"        "www"                 ""
+record_data  "192.0.2.1"  "target.example.com"  "192.0.2.2"
+record_aux   0            0                     0
+`
+	if got != want {
+		t.Errorf("recordComparison() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// splitImportID splits an import ID on unescaped slashes. A backslash escapes
+// the following character, so `\/` is a literal slash and `\\` a literal
+// backslash within a part.
+func splitImportID(id string) ([]string, error) {
+	var parts []string
+	var part strings.Builder
+
+	escaped := false
+	for _, c := range id {
+		switch {
+		case escaped:
+			part.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '/':
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteRune(c)
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("import ID %q ends with an unterminated escape", id)
+	}
+
+	return append(parts, part.String()), nil
+}
+
+// parseDNSImportID parses a `zone_host/record_id` import ID. The
+// `zone_host:record_id` format used by other DNS providers is accepted too,
+// so IDs of records migrated to All-Inkl can be reused in import blocks.
+func parseDNSImportID(id string) (zoneHost, recordID string, err error) {
+	parts, err := splitImportID(id)
+	if err != nil {
+		return "", "", err
+	}
+	if len(parts) == 1 {
+		if i := strings.LastIndex(parts[0], ":"); i >= 0 {
+			parts = []string{parts[0][:i], parts[0][i+1:]}
+		}
+	}
+
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("expected import ID in the format `zone_host/record_id` or `zone_host:record_id`, got: %q", id)
+	}
+
+	zoneHost, recordID = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if zoneHost == "" {
+		return "", "", fmt.Errorf("import ID %q has an empty zone_host", id)
+	}
+	if recordID == "" {
+		return "", "", fmt.Errorf("import ID %q has an empty record_id", id)
+	}
+	for _, c := range recordID {
+		if c < '0' || c > '9' {
+			return "", "", fmt.Errorf("import ID %q has a non-numeric record_id %q", id, recordID)
+		}
+	}
+
+	return zoneHost, recordID, nil
+}
+
+// dnsLookupImportID identifies a record by its zone, type, name and
+// optionally its data instead of its KAS record ID.
+type dnsLookupImportID struct {
+	ZoneHost   string
+	RecordType string
+	RecordName string
+	RecordData *string
+}
+
+// isDNSLookupImportID reports whether id has the form
+// `zone_host/record_type/record_name[/record_data]` rather than
+// `zone_host/record_id`.
+func isDNSLookupImportID(id string) bool {
+	parts, err := splitImportID(id)
+	return err == nil && len(parts) >= 3
+}
+
+// parseDNSLookupImportID parses a
+// `zone_host/record_type/record_name[/record_data]` import ID. record_name is
+// empty or "@" for the zone apex. Everything after the third slash is
+// record_data, so it may contain unescaped slashes.
+func parseDNSLookupImportID(id string) (dnsLookupImportID, error) {
+	parts, err := splitImportID(id)
+	if err != nil {
+		return dnsLookupImportID{}, err
+	}
+
+	if len(parts) < 3 {
+		return dnsLookupImportID{}, fmt.Errorf("expected import ID in the format `zone_host/record_type/record_name[/record_data]`, got: %q", id)
+	}
+
+	lookup := dnsLookupImportID{
+		ZoneHost:   strings.TrimSpace(parts[0]),
+		RecordType: strings.ToUpper(strings.TrimSpace(parts[1])),
+		RecordName: strings.TrimSpace(parts[2]),
+	}
+	if lookup.ZoneHost == "" {
+		return dnsLookupImportID{}, fmt.Errorf("import ID %q has an empty zone_host", id)
+	}
+	if lookup.RecordType == "" {
+		return dnsLookupImportID{}, fmt.Errorf("import ID %q has an empty record_type", id)
+	}
+	if len(parts) > 3 {
+		data := strings.Join(parts[3:], "/")
+		lookup.RecordData = &data
+	}
+
+	return lookup, nil
+}
+
+// formatDNSImportID returns the `zone_host/record_id` import ID of a record,
+// escaping slashes and backslashes in zoneHost.
+func formatDNSImportID(zoneHost, recordID string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, "/", `\/`).Replace(zoneHost)
+	return escaped + "/" + recordID
+}
+
+// verifyImportedDNSRecord reports an error naming importID if the record it
+// refers to does not exist. Resources call it from ImportState, so a wrong
+// ID fails there rather than in the Read following the import, whose error
+// does not mention the import.
+func verifyImportedDNSRecord(ctx context.Context, data *allinklProviderData, importID, zoneHost, recordID string) diag.Diagnostics {
+	record, diags := readDNSRecord(ctx, data, zoneHost, recordID, nil)
+	if diags.HasError() || record != nil {
+		return diags
+	}
+
+	diags.AddError(
+		"AllInkl DNS Record Not Found",
+		fmt.Sprintf("Record %s not found in zone %s. Check the import ID %q; the zone's record IDs are listed by the allinkl_dns_records data source.",
+			recordID, zoneHost, importID),
+	)
+	return diags
+}
+
+// verifyImportedDNSZone reports an error naming importID if zoneHost is not
+// a zone of the account.
+func verifyImportedDNSZone(ctx context.Context, data *allinklProviderData, importID, zoneHost string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, err := data.Client.GetDNSSettings(ctx, toASCIIHostname(zoneHost), "")
+	if allinkl.IsNotFound(err) {
+		diags.AddError(
+			"AllInkl DNS Zone Not Found",
+			fmt.Sprintf("Zone %s of import ID %q is not managed by the account.", zoneHost, importID),
+		)
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Reading AllInkl DNS Zone",
+			"Could not read zone "+zoneHost+": "+data.kasErrorMessage(err),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+)
+
+func TestParseDNSImportID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		id           string
+		wantZoneHost string
+		wantRecordID string
+		wantErr      bool
+	}{
+		"valid": {
+			id:           "example.com/12345",
+			wantZoneHost: "example.com",
+			wantRecordID: "12345",
+		},
+		"trailing-dot-zone": {
+			id:           "example.com./12345",
+			wantZoneHost: "example.com.",
+			wantRecordID: "12345",
+		},
+		"escaped-slash": {
+			id:           `odd\/zone.example.com/42`,
+			wantZoneHost: "odd/zone.example.com",
+			wantRecordID: "42",
+		},
+		"escaped-backslash": {
+			id:           `odd\\zone.example.com/42`,
+			wantZoneHost: `odd\zone.example.com`,
+			wantRecordID: "42",
+		},
+		"colon-separator": {
+			id:           "example.com:12345",
+			wantZoneHost: "example.com",
+			wantRecordID: "12345",
+		},
+		"colon-separator-non-numeric": {
+			id:      "example.com:abc",
+			wantErr: true,
+		},
+		"empty": {
+			id:      "",
+			wantErr: true,
+		},
+		"missing-separator": {
+			id:      "example.com",
+			wantErr: true,
+		},
+		"missing-zone": {
+			id:      "/12345",
+			wantErr: true,
+		},
+		"missing-record-id": {
+			id:      "example.com/",
+			wantErr: true,
+		},
+		"too-many-parts": {
+			id:      "example.com/A/12345",
+			wantErr: true,
+		},
+		"non-numeric-record-id": {
+			id:      "example.com/abc",
+			wantErr: true,
+		},
+		"unterminated-escape": {
+			id:      `example.com/12345\`,
+			wantErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			zoneHost, recordID, err := parseDNSImportID(testCase.id)
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got zone_host %q and record_id %q", testCase.id, zoneHost, recordID)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if zoneHost != testCase.wantZoneHost {
+				t.Errorf("expected zone_host %q, got %q", testCase.wantZoneHost, zoneHost)
+			}
+			if recordID != testCase.wantRecordID {
+				t.Errorf("expected record_id %q, got %q", testCase.wantRecordID, recordID)
+			}
+		})
+	}
+}
+
+func TestFormatDNSImportID(t *testing.T) {
+	t.Parallel()
+
+	for _, zoneHost := range []string{"example.com", "odd/zone.example.com", `odd\zone.example.com`} {
+		id := formatDNSImportID(zoneHost, "42")
+
+		gotZoneHost, gotRecordID, err := parseDNSImportID(id)
+		if err != nil {
+			t.Fatalf("parseDNSImportID(%q): unexpected error: %s", id, err)
+		}
+		if gotZoneHost != zoneHost || gotRecordID != "42" {
+			t.Errorf("parseDNSImportID(%q) = %q, %q, want %q, %q", id, gotZoneHost, gotRecordID, zoneHost, "42")
+		}
+	}
+}
+
+func TestZoneImportIDs(t *testing.T) {
+	t.Parallel()
+
+	records := []allinkl.ReturnInfo{
+		{ID: "1", RecordType: "NS", Changeable: "N"},
+		{ID: "2", RecordType: "A", Changeable: "Y"},
+		{ID: "3", RecordType: "MX", Changeable: "Y"},
+	}
+
+	got := zoneImportIDs("example.com", records, "")
+	want := map[string]string{"2": "example.com/2", "3": "example.com/3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("zoneImportIDs: got %v, want %v", got, want)
+	}
+
+	got = zoneImportIDs("example.com", records, "mx")
+	want = map[string]string{"3": "example.com/3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("zoneImportIDs filtered by type: got %v, want %v", got, want)
+	}
+}
+
+func TestParseDNSLookupImportID(t *testing.T) {
+	t.Parallel()
+
+	data := func(s string) *string { return &s }
+
+	testCases := map[string]struct {
+		id      string
+		want    dnsLookupImportID
+		wantErr bool
+	}{
+		"without-data": {
+			id:   "example.com/A/www",
+			want: dnsLookupImportID{ZoneHost: "example.com", RecordType: "A", RecordName: "www"},
+		},
+		"apex": {
+			id:   "example.com/mx/",
+			want: dnsLookupImportID{ZoneHost: "example.com", RecordType: "MX", RecordName: ""},
+		},
+		"with-data": {
+			id:   "example.com/TXT/www/v=spf1 -all",
+			want: dnsLookupImportID{ZoneHost: "example.com", RecordType: "TXT", RecordName: "www", RecordData: data("v=spf1 -all")},
+		},
+		"data-with-slashes": {
+			id:   `example.com/CAA//0 iodef "https://example.com/report"`,
+			want: dnsLookupImportID{ZoneHost: "example.com", RecordType: "CAA", RecordName: "", RecordData: data(`0 iodef "https://example.com/report"`)},
+		},
+		"record-id": {
+			id:      "example.com/12345",
+			wantErr: true,
+		},
+		"missing-zone": {
+			id:      "/A/www",
+			wantErr: true,
+		},
+		"missing-type": {
+			id:      "example.com//www",
+			wantErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseDNSLookupImportID(testCase.id)
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got %+v", testCase.id, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.ZoneHost != testCase.want.ZoneHost || got.RecordType != testCase.want.RecordType || got.RecordName != testCase.want.RecordName {
+				t.Errorf("expected %+v, got %+v", testCase.want, got)
+			}
+			if (got.RecordData == nil) != (testCase.want.RecordData == nil) ||
+				(got.RecordData != nil && *got.RecordData != *testCase.want.RecordData) {
+				t.Errorf("expected record_data %v, got %v", testCase.want.RecordData, got.RecordData)
+			}
+		})
+	}
+}
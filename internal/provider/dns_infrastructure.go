@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// infrastructureRecordTypes lists the record types that delegate a zone or
+// its subdomains. Breaking them can take the whole domain offline.
+var infrastructureRecordTypes = map[string]bool{
+	"NS":  true,
+	"SOA": true,
+}
+
+// infrastructureRecordWarning returns a warning listing the infrastructure
+// records the plan deletes or creates when moving from state to plan, or no
+// diagnostics if none are affected. Records of unknown type are ignored.
+func infrastructureRecordWarning(zoneHost string, state, plan []dnsZoneRecordModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var changes []string
+	for _, record := range state {
+		if isInfrastructureRecord(record) && !containsZoneRecordModel(plan, record) {
+			changes = append(changes, "  - delete "+describeZoneRecord(record))
+		}
+	}
+	for _, record := range plan {
+		if isInfrastructureRecord(record) && !containsZoneRecordModel(state, record) {
+			changes = append(changes, "  + create "+describeZoneRecord(record))
+		}
+	}
+	if len(changes) == 0 {
+		return diags
+	}
+
+	diags.AddWarning(
+		"Planned Change to Infrastructure Records",
+		fmt.Sprintf("This plan changes infrastructure records of zone %s:\n\n%s\n\n"+
+			"NS records delegate the zone or its subdomains; a mistake can take the whole domain offline. "+
+			"Double-check the change before applying it.", zoneHost, strings.Join(changes, "\n")),
+	)
+	return diags
+}
+
+// protectInfrastructureRecords returns an error listing the infrastructure
+// records deleted or replaced when moving from state to plan, unless
+// protect_system_records is disabled. Planned records with unknown values
+// may match any record of their type.
+func (d *allinklProviderData) protectInfrastructureRecords(zoneHost string, state, plan []dnsZoneRecordModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !d.protectSystemRecords() {
+		return diags
+	}
+
+	var deletions []string
+	for _, record := range state {
+		if isInfrastructureRecord(record) && !mayContainZoneRecordModel(plan, record) {
+			deletions = append(deletions, "  - "+describeZoneRecord(record))
+		}
+	}
+	if len(deletions) == 0 {
+		return diags
+	}
+
+	diags.AddError(
+		"Infrastructure Records Protected",
+		fmt.Sprintf("This plan deletes or replaces infrastructure records of zone %s:\n\n%s\n\n"+
+			"Deleting NS records can take the whole domain offline, so the provider refuses to do so while protect_system_records is enabled. "+
+			"Set protect_system_records = false in the provider configuration for the run that is meant to delete them.",
+			zoneHost, strings.Join(deletions, "\n")),
+	)
+	return diags
+}
+
+// delegationChangeError returns an error listing the apex NS records created,
+// changed or deleted when moving from state to plan, or no diagnostics if
+// the delegation of the zone stays as it is.
+func delegationChangeError(zoneHost string, state, plan []dnsZoneRecordModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var changes []string
+	for _, record := range state {
+		if isApexNSRecord(record) && !containsZoneRecordModel(plan, record) {
+			changes = append(changes, "  - "+describeZoneRecord(record))
+		}
+	}
+	for _, record := range plan {
+		if isApexNSRecord(record) && !containsZoneRecordModel(state, record) {
+			changes = append(changes, "  + "+describeZoneRecord(record))
+		}
+	}
+	if len(changes) == 0 {
+		return diags
+	}
+
+	diags.AddError(
+		"Delegation Change Not Allowed",
+		fmt.Sprintf("This plan changes the apex NS records of zone %s:\n\n%s\n\n"+
+			"Apex NS records delegate the zone; changing them can take the whole domain offline. "+
+			"Set allow_delegation_change = true on the record if the change is intended.",
+			zoneHost, strings.Join(changes, "\n")),
+	)
+	return diags
+}
+
+// isApexNSRecord reports whether record is an NS record at the zone apex.
+// Records of unknown type or name are not.
+func isApexNSRecord(record dnsZoneRecordModel) bool {
+	if record.Type.IsUnknown() || record.Name.IsUnknown() || !strings.EqualFold(record.Type.ValueString(), "NS") {
+		return false
+	}
+	name := record.Name.ValueString()
+	return name == "" || name == apexRecordName
+}
+
+// mayContainZoneRecordModel reports whether records contains record, treating
+// unknown values in records as matching.
+func mayContainZoneRecordModel(records []dnsZoneRecordModel, record dnsZoneRecordModel) bool {
+	for _, candidate := range records {
+		if candidate.Type.IsUnknown() {
+			return true
+		}
+		if !strings.EqualFold(candidate.Type.ValueString(), record.Type.ValueString()) {
+			continue
+		}
+		if (candidate.Name.IsUnknown() || candidate.Name.Equal(record.Name)) &&
+			(candidate.Data.IsUnknown() || recordDataValuesEqual(record.Type.ValueString(), candidate.Data, record.Data)) &&
+			(candidate.Aux.IsUnknown() || candidate.Aux.Equal(record.Aux)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isInfrastructureRecord(record dnsZoneRecordModel) bool {
+	return !record.Type.IsUnknown() && infrastructureRecordTypes[strings.ToUpper(record.Type.ValueString())]
+}
+
+func containsZoneRecordModel(records []dnsZoneRecordModel, record dnsZoneRecordModel) bool {
+	for _, candidate := range records {
+		if strings.EqualFold(candidate.Type.ValueString(), record.Type.ValueString()) &&
+			candidate.Name.Equal(record.Name) && recordDataValuesEqual(record.Type.ValueString(), candidate.Data, record.Data) &&
+			candidate.Aux.Equal(record.Aux) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeZoneRecord formats a record for diagnostics.
+func describeZoneRecord(record dnsZoneRecordModel) string {
+	name, data := record.Name.ValueString(), record.Data.ValueString()
+	if record.Name.IsUnknown() {
+		name = "(known after apply)"
+	} else if name == "" {
+		name = "@"
+	}
+	if record.Data.IsUnknown() {
+		data = "(known after apply)"
+	}
+	return fmt.Sprintf("%s %s %s", strings.ToUpper(record.Type.ValueString()), name, data)
+}
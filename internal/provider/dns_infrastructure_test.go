@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProtectInfrastructureRecords(t *testing.T) {
+	t.Parallel()
+
+	record := func(recordType, name string, data types.String) dnsZoneRecordModel {
+		return dnsZoneRecordModel{
+			Type: types.StringValue(recordType),
+			Name: types.StringValue(name),
+			Data: data,
+			Aux:  types.Int64Value(0),
+		}
+	}
+	ns := record("NS", "sub", types.StringValue("ns1.example.net"))
+	a := record("A", "www", types.StringValue("192.0.2.1"))
+
+	testCases := map[string]struct {
+		data      *allinklProviderData
+		state     []dnsZoneRecordModel
+		plan      []dnsZoneRecordModel
+		wantError bool
+	}{
+		"ns-kept":          {state: []dnsZoneRecordModel{ns, a}, plan: []dnsZoneRecordModel{ns}},
+		"ns-deleted":       {state: []dnsZoneRecordModel{ns, a}, plan: []dnsZoneRecordModel{a}, wantError: true},
+		"ns-changed":       {state: []dnsZoneRecordModel{ns}, plan: []dnsZoneRecordModel{record("NS", "sub", types.StringValue("ns2.example.net"))}, wantError: true},
+		"ns-case-changed":  {state: []dnsZoneRecordModel{ns}, plan: []dnsZoneRecordModel{record("NS", "sub", types.StringValue("NS1.Example.net"))}},
+		"ns-unknown-data":  {state: []dnsZoneRecordModel{ns}, plan: []dnsZoneRecordModel{record("NS", "sub", types.StringUnknown())}},
+		"ns-destroyed":     {state: []dnsZoneRecordModel{ns}, wantError: true},
+		"protection-off":   {data: &allinklProviderData{}, state: []dnsZoneRecordModel{ns}},
+		"other-deleted":    {state: []dnsZoneRecordModel{ns, a}, plan: []dnsZoneRecordModel{ns}},
+		"protection-on":    {data: &allinklProviderData{ProtectSystemRecords: true}, state: []dnsZoneRecordModel{ns}, wantError: true},
+		"nothing-in-state": {plan: []dnsZoneRecordModel{ns}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := testCase.data.protectInfrastructureRecords("example.com", testCase.state, testCase.plan)
+			if diags.HasError() != testCase.wantError {
+				t.Errorf("HasError() = %t, want %t: %v", diags.HasError(), testCase.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestDelegationChangeError(t *testing.T) {
+	t.Parallel()
+
+	record := func(recordType, name, data string) dnsZoneRecordModel {
+		return dnsZoneRecordModel{
+			Type: types.StringValue(recordType),
+			Name: types.StringValue(name),
+			Data: types.StringValue(data),
+			Aux:  types.Int64Value(0),
+		}
+	}
+	apexNS := record("NS", "", "ns1.example.net")
+
+	testCases := map[string]struct {
+		state     []dnsZoneRecordModel
+		plan      []dnsZoneRecordModel
+		wantError bool
+	}{
+		"apex-ns-unchanged":    {state: []dnsZoneRecordModel{apexNS}, plan: []dnsZoneRecordModel{apexNS}},
+		"apex-ns-case-changed": {state: []dnsZoneRecordModel{apexNS}, plan: []dnsZoneRecordModel{record("NS", "", "NS1.example.net")}},
+		"apex-ns-changed":      {state: []dnsZoneRecordModel{apexNS}, plan: []dnsZoneRecordModel{record("NS", "", "ns2.example.net")}, wantError: true},
+		"apex-ns-deleted":      {state: []dnsZoneRecordModel{apexNS}, wantError: true},
+		"apex-ns-created":      {plan: []dnsZoneRecordModel{record("NS", "@", "ns1.example.net")}, wantError: true},
+		"sub-ns-changed":       {state: []dnsZoneRecordModel{record("NS", "sub", "ns1.example.net")}, plan: []dnsZoneRecordModel{record("NS", "sub", "ns2.example.net")}},
+		"apex-a-changed":       {state: []dnsZoneRecordModel{record("A", "", "192.0.2.1")}, plan: []dnsZoneRecordModel{record("A", "", "192.0.2.2")}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := delegationChangeError("example.com", testCase.state, testCase.plan)
+			if diags.HasError() != testCase.wantError {
+				t.Errorf("HasError() = %t, want %t: %v", diags.HasError(), testCase.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestCheckMaxDeletions(t *testing.T) {
+	t.Parallel()
+
+	record := func(name, data string) dnsZoneRecordModel {
+		return dnsZoneRecordModel{
+			Type: types.StringValue("A"),
+			Name: types.StringValue(name),
+			Data: types.StringValue(data),
+			Aux:  types.Int64Value(0),
+		}
+	}
+	state := []dnsZoneRecordModel{record("www", "192.0.2.1"), record("mail", "192.0.2.2"), record("@", "192.0.2.3")}
+
+	testCases := map[string]struct {
+		plan         []dnsZoneRecordModel
+		maxDeletions types.Int64
+		wantError    bool
+	}{
+		"unlimited":       {maxDeletions: types.Int64Null()},
+		"unknown-limit":   {maxDeletions: types.Int64Unknown()},
+		"emptied":         {maxDeletions: types.Int64Value(2), wantError: true},
+		"within-limit":    {plan: state[:1], maxDeletions: types.Int64Value(2)},
+		"additions-only":  {plan: append(state, record("ftp", "192.0.2.4")), maxDeletions: types.Int64Value(0)},
+		"changed-data":    {plan: []dnsZoneRecordModel{record("www", "192.0.2.9"), state[1], state[2]}, maxDeletions: types.Int64Value(0), wantError: true},
+		"unknown-planned": {plan: []dnsZoneRecordModel{record("www", "192.0.2.1"), {Type: types.StringUnknown(), Name: types.StringUnknown(), Data: types.StringUnknown(), Aux: types.Int64Unknown()}}, maxDeletions: types.Int64Value(0)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := checkMaxDeletions("example.com", testCase.maxDeletions, plannedDeletions(state, testCase.plan))
+			if diags.HasError() != testCase.wantError {
+				t.Errorf("checkMaxDeletions() error = %v, want %v: %v", diags.HasError(), testCase.wantError, diags)
+			}
+		})
+	}
+}
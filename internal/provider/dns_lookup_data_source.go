@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &dnsLookupDataSource{}
+)
+
+// NewDNSLookupDataSource is a helper function to simplify the provider implementation.
+func NewDNSLookupDataSource() datasource.DataSource {
+	return &dnsLookupDataSource{}
+}
+
+// dnsLookupDataSource is the data source implementation. It queries
+// nameservers directly and does not use the KAS API.
+type dnsLookupDataSource struct{}
+
+// dnsLookupDataSourceModel maps the data source schema data.
+type dnsLookupDataSourceModel struct {
+	ZoneHost    types.String           `tfsdk:"zone_host"`
+	RecordType  types.String           `tfsdk:"record_type"`
+	RecordName  types.String           `tfsdk:"record_name"`
+	Nameservers []types.String         `tfsdk:"nameservers"`
+	FQDN        types.String           `tfsdk:"fqdn"`
+	Values      []types.String         `tfsdk:"values"`
+	Consistent  types.Bool             `tfsdk:"consistent"`
+	Answers     []dnsLookupAnswerModel `tfsdk:"answers"`
+}
+
+// dnsLookupAnswerModel maps the answer of one nameserver.
+type dnsLookupAnswerModel struct {
+	Nameserver types.String   `tfsdk:"nameserver"`
+	Values     []types.String `tfsdk:"values"`
+}
+
+// Metadata returns the data source type name.
+func (d *dnsLookupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_lookup"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsLookupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves a record by querying nameservers directly instead of the KAS API, to verify what the public " +
+			"nameservers serve for a zone delegated to All-Inkl, e.g. against the `allinkl_dns_records` data source. " +
+			"Supported for A, AAAA, CNAME, MX, NS and TXT records.",
+		Attributes: map[string]schema.Attribute{
+			"zone_host": schema.StringAttribute{
+				Required: true,
+			},
+			"record_type": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringOneOf("A", "AAAA", "CNAME", "MX", "NS", "TXT"),
+				},
+			},
+			"record_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The record name, empty or `@` for the zone apex. Defaults to the apex.",
+			},
+			"nameservers": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Nameservers to query. Defaults to the All-Inkl nameservers.",
+			},
+			"fqdn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The fully qualified name that was resolved.",
+			},
+			"values": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The record data served by any of the nameservers, sorted and formatted like `record_data`.",
+			},
+			"consistent": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether every nameserver answered and served the same record data.",
+			},
+			"answers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The record data served by each nameserver that answered.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"nameserver": schema.StringAttribute{
+							Computed: true,
+						},
+						"values": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsLookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state dnsLookupDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameservers := defaultNameservers
+	if state.Nameservers != nil {
+		nameservers = nil
+		for _, nameserver := range state.Nameservers {
+			nameservers = append(nameservers, nameserver.ValueString())
+		}
+	}
+
+	recordType := state.RecordType.ValueString()
+	fqdn := recordFQDN(toKASRecordName(state.RecordName.ValueString()), state.ZoneHost.ValueString())
+	state.FQDN = types.StringValue(fqdn)
+	state.Consistent = types.BoolValue(true)
+	state.Answers = []dnsLookupAnswerModel{}
+
+	var answers [][]string
+	for _, nameserver := range nameservers {
+		values, err := lookupRecordData(ctx, nameserver, recordType, fqdn+".")
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			values, err = nil, nil
+		}
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"AllInkl DNS Lookup Failed",
+				fmt.Sprintf("Could not resolve the %s record %s on %s: %s", recordType, fqdn, nameserver, err),
+			)
+			state.Consistent = types.BoolValue(false)
+			continue
+		}
+
+		values = canonicalLookupValues(recordType, values)
+		answers = append(answers, values)
+		state.Answers = append(state.Answers, dnsLookupAnswerModel{
+			Nameserver: types.StringValue(nameserver),
+			Values:     stringValues(values),
+		})
+	}
+
+	var union []string
+	for i, values := range answers {
+		if i > 0 && !sameRecordData(recordType, answers[0], values) {
+			state.Consistent = types.BoolValue(false)
+		}
+		for _, value := range values {
+			if !containsRecordData(recordType, union, value) {
+				union = append(union, value)
+			}
+		}
+	}
+	sort.Strings(union)
+	state.Values = stringValues(union)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// canonicalLookupValues strips the trailing dot of hostnames returned by
+// the resolver, so values match the KAS record_data format, and sorts them.
+func canonicalLookupValues(recordType string, values []string) []string {
+	canonical := make([]string, 0, len(values))
+	for _, value := range values {
+		if hostnameRecordTypes[recordType] {
+			value = strings.TrimSuffix(value, ".")
+		}
+		canonical = append(canonical, value)
+	}
+	sort.Strings(canonical)
+	return canonical
+}
+
+// sameRecordData reports whether a and b hold the same record data,
+// regardless of order.
+func sameRecordData(recordType string, a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, value := range a {
+		if !containsRecordData(recordType, b, value) {
+			return false
+		}
+	}
+	for _, value := range b {
+		if !containsRecordData(recordType, a, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// stringValues converts strings to string values.
+func stringValues(values []string) []types.String {
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
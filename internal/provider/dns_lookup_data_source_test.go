@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalLookupValues(t *testing.T) {
+	t.Parallel()
+
+	got := canonicalLookupValues("MX", []string{"mx2.example.com.", "mx1.example.com."})
+	if want := []string{"mx1.example.com", "mx2.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("canonicalLookupValues(MX) = %v, want %v", got, want)
+	}
+
+	got = canonicalLookupValues("TXT", []string{"ends with a dot."})
+	if want := []string{"ends with a dot."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("canonicalLookupValues(TXT) = %v, want %v", got, want)
+	}
+}
+
+func TestSameRecordData(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		recordType string
+		a, b       []string
+		want       bool
+	}{
+		"same-order":      {recordType: "A", a: []string{"192.0.2.1", "192.0.2.2"}, b: []string{"192.0.2.2", "192.0.2.1"}, want: true},
+		"missing":         {recordType: "A", a: []string{"192.0.2.1", "192.0.2.2"}, b: []string{"192.0.2.1"}, want: false},
+		"hostname-case":   {recordType: "CNAME", a: []string{"Target.example.com"}, b: []string{"target.example.com"}, want: true},
+		"txt-case":        {recordType: "TXT", a: []string{"Token"}, b: []string{"token"}, want: false},
+		"both-empty":      {recordType: "TXT", want: true},
+		"duplicate-value": {recordType: "A", a: []string{"192.0.2.1", "192.0.2.1"}, b: []string{"192.0.2.1", "192.0.2.2"}, want: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := sameRecordData(testCase.recordType, testCase.a, testCase.b); got != testCase.want {
+				t.Errorf("sameRecordData(%v, %v) = %t, want %t", testCase.a, testCase.b, got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// MoveState moves allinkl_dns resources to allinkl_dns_record, so a moved
+// block can rename them without recreating the records. State of earlier
+// allinkl_dns schema versions is upgraded on the way.
+func (r *dnsResource) MoveState(ctx context.Context) []resource.StateMover {
+	if r.deprecatedAlias {
+		return nil
+	}
+
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	sourceSchemas := map[int64]schema.Schema{
+		0:                        dnsResourceSchemaV0(ctx, r),
+		1:                        dnsResourceSchemaV1(ctx, r),
+		dnsResourceSchemaVersion: resp.Schema,
+	}
+
+	movers := make([]resource.StateMover, 0, len(sourceSchemas))
+	for version, sourceSchema := range sourceSchemas {
+		movers = append(movers, resource.StateMover{
+			SourceSchema: &sourceSchema,
+			StateMover:   moveDNSResourceState(version),
+		})
+	}
+	return movers
+}
+
+// moveDNSResourceState returns a state mover accepting allinkl_dns state of
+// the given schema version from this provider.
+func moveDNSResourceState(version int64) func(context.Context, resource.MoveStateRequest, *resource.MoveStateResponse) {
+	return func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+		if req.SourceTypeName != "allinkl_dns" || req.SourceSchemaVersion != version ||
+			!strings.HasSuffix(req.SourceProviderAddress, "/allinkl") || req.SourceState == nil {
+			return
+		}
+
+		var state dnsResourceModel
+		resp.Diagnostics.Append(req.SourceState.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.TargetState.Set(ctx, upgradeDNSResourceModel(state, version))...)
+		resp.TargetPrivate = req.SourcePrivate
+	}
+}
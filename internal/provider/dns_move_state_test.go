@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDNSRecordResourceMoveState(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !schemaResp.ResourceSchemas["allinkl_dns"].Block.Deprecated {
+		t.Errorf("expected allinkl_dns to be deprecated")
+	}
+	if schemaResp.ResourceSchemas["allinkl_dns_record"].Block.Deprecated {
+		t.Errorf("expected allinkl_dns_record not to be deprecated")
+	}
+
+	resp, err := server.MoveResourceState(context.Background(), &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/vimaster/allinkl",
+		SourceTypeName:        "allinkl_dns",
+		SourceSchemaVersion:   1,
+		SourceState: &tfprotov6.RawState{
+			JSON: []byte(`{"id":"12345","last_updated":"Monday, 02-Jan-06 15:04:05 UTC","zone_host":"example.com","record_type":"A","record_name":"www","record_data":"192.0.2.1","record_aux":0}`),
+		},
+		TargetTypeName: "allinkl_dns_record",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	state, err := resp.TargetState.Unmarshal(schemaResp.ResourceSchemas["allinkl_dns_record"].ValueType())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var id, lastUpdated string
+	if err := attributes["id"].As(&id); err != nil || id != "12345" {
+		t.Errorf("expected id %q, got %q (%v)", "12345", id, err)
+	}
+	if err := attributes["last_updated"].As(&lastUpdated); err != nil || lastUpdated != "2006-01-02T15:04:05Z" {
+		t.Errorf("expected last_updated %q, got %q (%v)", "2006-01-02T15:04:05Z", lastUpdated, err)
+	}
+}
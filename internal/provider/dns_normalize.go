@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/idna"
+)
+
+// hostnameRecordTypes lists the record types whose record_data is a single
+// hostname.
+var hostnameRecordTypes = map[string]bool{
+	"CNAME": true,
+	"MX":    true,
+	"NS":    true,
+}
+
+// normalizeHostname lowercases a hostname, strips the trailing dot of a
+// fully qualified name and converts internationalized labels to punycode.
+func normalizeHostname(host string) string {
+	return toASCIIHostname(strings.TrimSuffix(strings.ToLower(host), "."))
+}
+
+// toASCIIHostname converts internationalized labels of a hostname (or record
+// name) to their punycode form as expected by KAS. Labels that are already
+// ASCII, including service labels such as _acme-challenge, are kept as is.
+func toASCIIHostname(host string) string {
+	ascii, err := idna.Punycode.ToASCII(host)
+	if err != nil {
+		return host
+	}
+	return ascii
+}
+
+// apexRecordName is the conventional name of the zone apex. KAS expects an
+// empty record name instead.
+const apexRecordName = "@"
+
+// toKASRecordName converts a record name to the form KAS expects: "@"
+// becomes the empty apex name and internationalized labels are converted to
+// punycode.
+func toKASRecordName(name string) string {
+	if name == apexRecordName {
+		return ""
+	}
+	return toASCIIHostname(name)
+}
+
+// recordNameValue returns the known record_name if it denotes the same name
+// as the remote value, so internationalized names written in Unicode don't
+// drift against the punycode form returned by KAS.
+func recordNameValue(known types.String, remote string) types.String {
+	if !known.IsNull() && !known.IsUnknown() && hostnameEqual(toKASRecordName(known.ValueString()), remote) {
+		return known
+	}
+
+	return types.StringValue(remote)
+}
+
+// hostnameEqual reports whether two hostnames only differ in case or a
+// trailing dot.
+func hostnameEqual(a, b string) bool {
+	return normalizeHostname(a) == normalizeHostname(b)
+}
+
+// recordFQDN returns the fully qualified name of a record, without trailing
+// dot. An empty record name or "@" denotes the zone apex.
+func recordFQDN(recordName, zoneHost string) string {
+	zone := normalizeHostname(zoneHost)
+	if recordName == "" || recordName == apexRecordName {
+		return zone
+	}
+	return normalizeHostname(recordName) + "." + zone
+}
+
+// recordDataEqual reports whether two record_data values of the given record
+// type describe the same record content. Hostname targets compare
+// case-insensitively; TXT content stays byte-exact apart from quoting.
+func recordDataEqual(recordType, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	recordType = strings.ToUpper(recordType)
+	switch {
+	case hostnameRecordTypes[recordType]:
+		return hostnameEqual(a, b)
+	case recordType == "SRV":
+		// "<weight> <port> <target>": only the target is a hostname.
+		fieldsA, fieldsB := strings.Fields(a), strings.Fields(b)
+		if len(fieldsA) != len(fieldsB) || len(fieldsA) == 0 {
+			return false
+		}
+		last := len(fieldsA) - 1
+		return strings.Join(fieldsA[:last], " ") == strings.Join(fieldsB[:last], " ") &&
+			hostnameEqual(fieldsA[last], fieldsB[last])
+	case recordType == "TXT":
+		return unquoteTXT(a) == unquoteTXT(b)
+	case recordType == "CAA":
+		// The value may be quoted or not, e.g. `0 issue letsencrypt.org`.
+		return canonicalCAA(a) == canonicalCAA(b)
+	case recordType == "AAAA":
+		// "2001:db8::1" and "2001:0db8:0000::0001" are the same address.
+		addrA, errA := netip.ParseAddr(strings.TrimSpace(a))
+		addrB, errB := netip.ParseAddr(strings.TrimSpace(b))
+		return errA == nil && errB == nil && addrA == addrB
+	case recordType == "TLSA":
+		// KAS may return the hex payload in a different case.
+		return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+	}
+
+	return false
+}
+
+// recordDataValuesEqual reports whether two record_data attribute values
+// are equal under recordDataEqual. Null and unknown values are only equal to
+// themselves.
+func recordDataValuesEqual(recordType string, a, b types.String) bool {
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() {
+		return a.Equal(b)
+	}
+	return recordDataEqual(recordType, a.ValueString(), b.ValueString())
+}
+
+// recordDataValue returns the known value if it is equivalent to the remote
+// value, avoiding spurious diffs from KAS reformatting record data, and the
+// remote value otherwise.
+func recordDataValue(recordType string, known types.String, remote string) types.String {
+	if !known.IsNull() && !known.IsUnknown() && recordDataEqual(recordType, known.ValueString(), remote) {
+		return known
+	}
+
+	return types.StringValue(remote)
+}
+
+// zoneHostValue returns the known zone_host if it only differs from the
+// remote value in case or a trailing dot, and the remote value otherwise.
+func zoneHostValue(known types.String, remote string) types.String {
+	if !known.IsNull() && !known.IsUnknown() && hostnameEqual(known.ValueString(), remote) {
+		return known
+	}
+
+	return types.StringValue(remote)
+}
+
+// requiresReplaceIfZoneChanged forces replacement only if the zone_host
+// changes to a different zone, ignoring case and trailing dot changes.
+func requiresReplaceIfZoneChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !hostnameEqual(req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"If the zone changes, Terraform will destroy and recreate the resource. Case and trailing dot changes are ignored.",
+		"If the zone changes, Terraform will destroy and recreate the resource. Case and trailing dot changes are ignored.",
+	)
+}
+
+// canonicalAAAA returns the canonical form of an IPv6 address, or data
+// unchanged if it is not one.
+func canonicalAAAA(data string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(data))
+	if err != nil {
+		return data
+	}
+	return addr.String()
+}
+
+// canonicalSRV returns SRV record data with single spaces between fields and
+// the target hostname normalized.
+func canonicalSRV(data string) string {
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		return data
+	}
+	fields[len(fields)-1] = normalizeHostname(fields[len(fields)-1])
+	return strings.Join(fields, " ")
+}
+
+// unquoteTXT returns the content of TXT record data. Data consisting of
+// quoted character-strings, as produced by normalize_txt or by KAS for long
+// values, is unquoted and the chunks are joined. Any other data is returned
+// unchanged.
+func unquoteTXT(data string) string {
+	chunks, ok := parseCharacterStrings(data)
+	if !ok {
+		return data
+	}
+	return strings.Join(chunks, "")
+}
+
+// quoteCharacterString returns s as a quoted character-string in DNS
+// presentation format (RFC 1035 section 5.1): quotes and backslashes are
+// escaped with a backslash and control characters as \DDD. Other bytes,
+// including UTF-8 sequences, are kept.
+func quoteCharacterString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c == 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// parseCharacterStrings returns the contents of data consisting of quoted
+// character-strings in DNS presentation format separated by whitespace,
+// decoding \X and \DDD escapes. ok is false if data holds anything else.
+func parseCharacterStrings(data string) (chunks []string, ok bool) {
+	trimmed := strings.TrimSpace(data)
+	if !strings.HasPrefix(trimmed, `"`) {
+		return nil, false
+	}
+
+	var content []byte
+	inQuotes := false
+	for i := 0; i < len(trimmed); i++ {
+		c := trimmed[i]
+		switch {
+		case inQuotes && c == '\\':
+			if i+1 >= len(trimmed) {
+				return nil, false
+			}
+			if n, ok := decimalEscape(trimmed[i+1:]); ok {
+				content = append(content, n)
+				i += 3
+				continue
+			}
+			content = append(content, trimmed[i+1])
+			i++
+		case c == '"':
+			if inQuotes {
+				chunks = append(chunks, string(content))
+				content = content[:0]
+			}
+			inQuotes = !inQuotes
+		case inQuotes:
+			content = append(content, c)
+		case c == ' ' || c == '\t':
+			// Whitespace separating character-strings.
+		default:
+			// Text outside of quotes: not a list of character-strings.
+			return nil, false
+		}
+	}
+
+	if inQuotes {
+		return nil, false
+	}
+	return chunks, true
+}
+
+// decimalEscape decodes the DDD of a \DDD escape at the start of s.
+func decimalEscape(s string) (byte, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	n := 0
+	for _, c := range []byte(s[:3]) {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	if n > 255 {
+		return 0, false
+	}
+	return byte(n), true
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRecordDataEqualCase(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		recordType string
+		a, b       string
+		want       bool
+	}{
+		"cname":              {recordType: "CNAME", a: "Target.Example.com", b: "target.example.com.", want: true},
+		"cname-lowercase":    {recordType: "cname", a: "Target.Example.com", b: "target.example.com", want: true},
+		"mx":                 {recordType: "MX", a: "MAIL.example.com", b: "mail.example.com", want: true},
+		"ns":                 {recordType: "NS", a: "NS1.example.net", b: "ns1.example.net", want: true},
+		"srv-target":         {recordType: "SRV", a: "5 5060 SIP.example.com", b: "5 5060 sip.example.com.", want: true},
+		"txt":                {recordType: "TXT", a: "Hello World", b: "hello world", want: false},
+		"txt-quoted":         {recordType: "TXT", a: `"Hello World"`, b: "Hello World", want: true},
+		"txt-quoted-case":    {recordType: "TXT", a: `"Hello World"`, b: "hello world", want: false},
+		"cname-other-target": {recordType: "CNAME", a: "a.example.com", b: "b.example.com", want: false},
+		"caa-quoted":         {recordType: "CAA", a: `0 issue "letsencrypt.org"`, b: "0 issue letsencrypt.org", want: true},
+		"caa-spacing":        {recordType: "CAA", a: `0  issue   "letsencrypt.org"`, b: `0 issue "letsencrypt.org"`, want: true},
+		"caa-tag-case":       {recordType: "CAA", a: `0 ISSUE "letsencrypt.org"`, b: `0 issue "letsencrypt.org"`, want: true},
+		"caa-value-case":     {recordType: "CAA", a: `0 issue "LetsEncrypt.org"`, b: `0 issue "letsencrypt.org"`, want: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := recordDataEqual(testCase.recordType, testCase.a, testCase.b); got != testCase.want {
+				t.Errorf("recordDataEqual(%s, %q, %q) = %t, want %t", testCase.recordType, testCase.a, testCase.b, got, testCase.want)
+			}
+			if got := recordETag("example.com", testCase.recordType, "www", testCase.a, 0) == recordETag("example.com", testCase.recordType, "www", testCase.b, 0); got != testCase.want {
+				t.Errorf("recordETag equality for %q and %q = %t, want %t", testCase.a, testCase.b, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestRecordDataValuesEqual(t *testing.T) {
+	t.Parallel()
+
+	if !recordDataValuesEqual("CNAME", types.StringValue("Target.example.com"), types.StringValue("target.example.com")) {
+		t.Error("CNAME targets differing in case are not equal")
+	}
+	if recordDataValuesEqual("TXT", types.StringValue("Token"), types.StringValue("token")) {
+		t.Error("TXT values differing in case are equal")
+	}
+	if recordDataValuesEqual("CNAME", types.StringUnknown(), types.StringValue("target.example.com")) {
+		t.Error("unknown value is equal to a known value")
+	}
+	if !recordDataValuesEqual("CNAME", types.StringNull(), types.StringNull()) {
+		t.Error("null values are not equal")
+	}
+}
+
+func TestRecordDataEqualAAAA(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b string
+		want bool
+	}{
+		"identical":      {a: "2001:db8::1", b: "2001:db8::1", want: true},
+		"leading-zeros":  {a: "2001:db8::1", b: "2001:0db8:0000::0001", want: true},
+		"expanded":       {a: "2001:db8::1", b: "2001:0db8:0000:0000:0000:0000:0000:0001", want: true},
+		"case":           {a: "2001:db8::abcd", b: "2001:DB8::ABCD", want: true},
+		"different":      {a: "2001:db8::1", b: "2001:db8::2", want: false},
+		"invalid":        {a: "2001:db8::1", b: "not-an-address", want: false},
+		"ipv4-in-ipv6":   {a: "::ffff:192.0.2.1", b: "::ffff:c000:201", want: true},
+		"ipv4-vs-mapped": {a: "192.0.2.1", b: "::ffff:192.0.2.1", want: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := recordDataEqual("AAAA", testCase.a, testCase.b); got != testCase.want {
+				t.Errorf("recordDataEqual(AAAA, %q, %q) = %t, want %t", testCase.a, testCase.b, got, testCase.want)
+			}
+			if got := recordETag("example.com", "AAAA", "www", testCase.a, 0) == recordETag("example.com", "AAAA", "www", testCase.b, 0); got != testCase.want {
+				t.Errorf("recordETag equality for %q and %q = %t, want %t", testCase.a, testCase.b, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestCharacterStrings(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		content string
+		quoted  string
+	}{
+		"plain":     {content: "letsencrypt.org", quoted: `"letsencrypt.org"`},
+		"empty":     {content: "", quoted: `""`},
+		"quote":     {content: `say "hi"`, quoted: `"say \"hi\""`},
+		"backslash": {content: `C:\path`, quoted: `"C:\\path"`},
+		"control":   {content: "line1\nline2\x7f", quoted: `"line1\010line2\127"`},
+		"utf-8":     {content: "bücher", quoted: `"bücher"`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := quoteCharacterString(testCase.content); got != testCase.quoted {
+				t.Errorf("quoteCharacterString(%q) = %s, want %s", testCase.content, got, testCase.quoted)
+			}
+			if got := unquoteTXT(testCase.quoted); got != testCase.content {
+				t.Errorf("unquoteTXT(%s) = %q, want %q", testCase.quoted, got, testCase.content)
+			}
+		})
+	}
+}
+
+func TestUnquoteTXT(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		data string
+		want string
+	}{
+		"unquoted":          {data: "v=spf1 -all", want: "v=spf1 -all"},
+		"chunks":            {data: `"v=DKIM1; " "p=abc"`, want: "v=DKIM1; p=abc"},
+		"decimal-escape":    {data: `"a\059b\032c"`, want: "a;b c"},
+		"short-decimal":     {data: `"a\05"`, want: "a05"},
+		"decimal-too-large": {data: `"\256"`, want: "256"},
+		"text-outside":      {data: `"a" b`, want: `"a" b`},
+		"unterminated":      {data: `"abc`, want: `"abc`},
+		"trailing-escape":   {data: `"abc\`, want: `"abc\`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := unquoteTXT(testCase.data); got != testCase.want {
+				t.Errorf("unquoteTXT(%s) = %q, want %q", testCase.data, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParseCAARecordDataEscapes(t *testing.T) {
+	t.Parallel()
+
+	caa, err := parseCAARecordData(`0 issue "ca.example.net\059 account=230123"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := caa.Value.ValueString(); got != "ca.example.net; account=230123" {
+		t.Errorf("unexpected value %q", got)
+	}
+	if got := caa.recordData(); got != `0 issue "ca.example.net; account=230123"` {
+		t.Errorf("unexpected record data %s", got)
+	}
+}
+
+func TestParseCAARecordDataWhitespace(t *testing.T) {
+	t.Parallel()
+
+	caa, err := parseCAARecordData("128  issuewild \t\"ca.example.net;  policy=ev\"")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if caa.Flags.ValueInt64() != 128 || caa.Tag.ValueString() != "issuewild" || caa.Value.ValueString() != "ca.example.net;  policy=ev" {
+		t.Errorf("unexpected CAA %+v", caa)
+	}
+
+	if _, err := parseCAARecordData("0  issue"); err == nil {
+		t.Error("expected an error for CAA data without value")
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// dnsPrivateStateKey is the private state key holding dnsPrivateState.
+const dnsPrivateStateKey = "record"
+
+// privateStateGetter is implemented by the private state of framework
+// requests.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is implemented by the private state of framework
+// responses.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// dnsPrivateState is provider-only data stored alongside a DNS record in
+// the Terraform state.
+type dnsPrivateState struct {
+	// ETag is a content hash of the record as last seen by the provider.
+	ETag string `json:"etag,omitempty"`
+	// ReadAt is when the provider last fetched or wrote the record.
+	ReadAt time.Time `json:"read_at,omitempty"`
+	// Changeable is the record_changeable flag KAS last reported for the
+	// record. Nil for private state written before it was tracked.
+	Changeable *bool `json:"changeable,omitempty"`
+}
+
+// remoteChangeable returns the record_changeable flag of a record returned
+// by KAS for the private state.
+func remoteChangeable(record allinkl.ReturnInfo) *bool {
+	changeable := record.Changeable == "Y"
+	return &changeable
+}
+
+// isFresh reports whether the record was fetched within maxAge.
+func (d dnsPrivateState) isFresh(maxAge time.Duration) bool {
+	return maxAge > 0 && !d.ReadAt.IsZero() && time.Since(d.ReadAt) < maxAge
+}
+
+// recordETag returns a content hash identifying the given record values.
+// Hostnames and TXT quoting are normalized so equivalent spellings share
+// the same ETag.
+func recordETag(zoneHost, recordType, recordName, recordData string, recordAux int) string {
+	recordType = strings.ToUpper(recordType)
+	switch {
+	case hostnameRecordTypes[recordType]:
+		recordData = normalizeHostname(recordData)
+	case recordType == "SRV":
+		recordData = canonicalSRV(recordData)
+	case recordType == "TXT":
+		recordData = unquoteTXT(recordData)
+	case recordType == "CAA":
+		recordData = canonicalCAA(recordData)
+	case recordType == "AAAA":
+		recordData = canonicalAAAA(recordData)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		normalizeHostname(zoneHost),
+		recordType,
+		recordName,
+		recordData,
+		fmt.Sprint(recordAux),
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// remoteRecordETag returns the content hash of a record returned by KAS.
+func remoteRecordETag(record allinkl.ReturnInfo) string {
+	return recordETag(record.ZoneHost, record.RecordType, record.RecordName, record.RecordData, record.RecordAux)
+}
+
+func getDNSPrivateState(ctx context.Context, private privateStateGetter) (dnsPrivateState, diag.Diagnostics) {
+	var data dnsPrivateState
+
+	raw, diags := private.GetKey(ctx, dnsPrivateStateKey)
+	if diags.HasError() || len(raw) == 0 {
+		return data, diags
+	}
+
+	if err := json.Unmarshal(raw, &data); err != nil {
+		diags.AddError(
+			"Error Reading AllInkl DNS Private State",
+			"Could not decode private state: "+err.Error(),
+		)
+	}
+
+	return data, diags
+}
+
+func setDNSPrivateState(ctx context.Context, private privateStateSetter, data dnsPrivateState) diag.Diagnostics {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error Writing AllInkl DNS Private State",
+			"Could not encode private state: "+err.Error(),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, dnsPrivateStateKey, raw)
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultNameservers are the All-Inkl nameservers queried when no resolvers
+// are configured.
+var defaultNameservers = []string{"ns5.kasserver.com", "ns6.kasserver.com"}
+
+// propagationPollInterval is the delay between two propagation checks.
+var propagationPollInterval = 5 * time.Second
+
+// nameserverResolver returns a resolver that sends every query to the given
+// nameserver instead of the system resolvers.
+func nameserverResolver(nameserver string) *net.Resolver {
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		nameserver = net.JoinHostPort(nameserver, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, nameserver)
+		},
+	}
+}
+
+// lookupRecordData returns the record data served by nameserver for the given
+// record type and name, formatted like KAS record_data.
+func lookupRecordData(ctx context.Context, nameserver, recordType, fqdn string) ([]string, error) {
+	resolver := nameserverResolver(nameserver)
+
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			values = append(values, ip.String())
+		}
+		return values, nil
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		return []string{cname}, nil
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(mxs))
+		for _, mx := range mxs {
+			values = append(values, mx.Host)
+		}
+		return values, nil
+	case "NS":
+		nss, err := resolver.LookupNS(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(nss))
+		for _, ns := range nss {
+			values = append(values, ns.Host)
+		}
+		return values, nil
+	case "TXT":
+		return resolver.LookupTXT(ctx, fqdn)
+	default:
+		return nil, fmt.Errorf("propagation checks are not supported for %s records", recordType)
+	}
+}
+
+// waitForPropagation polls every nameserver until each one serves
+// recordData for the given record type and name, or ctx is done.
+func waitForPropagation(ctx context.Context, nameservers []string, recordType, fqdn, recordData string) error {
+	pending := append([]string(nil), nameservers...)
+
+	for {
+		var remaining []string
+		for _, nameserver := range pending {
+			values, err := lookupRecordData(ctx, nameserver, recordType, fqdn)
+			if !containsRecordData(recordType, values, recordData) {
+				tflog.Debug(ctx, "Record not yet propagated", map[string]any{
+					"nameserver": nameserver,
+					"fqdn":       fqdn,
+					"type":       recordType,
+					"served":     strings.Join(values, ", "),
+					"error":      fmt.Sprint(err),
+				})
+				remaining = append(remaining, nameserver)
+			}
+		}
+
+		if len(remaining) == 0 {
+			return nil
+		}
+		pending = remaining
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s record %s not visible on %s: %w", recordType, fqdn, strings.Join(pending, ", "), ctx.Err())
+		case <-time.After(propagationPollInterval):
+		}
+	}
+}
+
+func containsRecordData(recordType string, values []string, recordData string) bool {
+	for _, value := range values {
+		if recordDataEqual(recordType, value, recordData) {
+			return true
+		}
+	}
+	return false
+}
+
+// propagationCheckRecordTypes lists the record types lookupRecordData
+// supports.
+var propagationCheckRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+	"MX":    true,
+	"NS":    true,
+	"TXT":   true,
+}
+
+// dnsWaitForPropagationModel maps the wait_for_propagation attributes.
+type dnsWaitForPropagationModel struct {
+	Nameservers types.List   `tfsdk:"nameservers"`
+	Timeout     types.String `tfsdk:"timeout"`
+}
+
+// waitForPropagationSchemaAttribute returns the schema of the
+// wait_for_propagation attribute.
+func waitForPropagationSchemaAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Wait after create and update until the record is served by the given nameservers. " +
+			"Supported for A, AAAA, CNAME, MX, NS and TXT records.",
+		Attributes: map[string]schema.Attribute{
+			"nameservers": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(defaultNameserversValue()),
+				MarkdownDescription: "Nameservers to query. Defaults to the All-Inkl nameservers.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("5m"),
+				MarkdownDescription: "Maximum time to wait. Defaults to `5m`.",
+				Validators: []validator.String{
+					duration(),
+				},
+			},
+		},
+	}
+}
+
+// defaultNameserversValue returns defaultNameservers as a list value.
+func defaultNameserversValue() types.List {
+	values := make([]attr.Value, 0, len(defaultNameservers))
+	for _, nameserver := range defaultNameservers {
+		values = append(values, types.StringValue(nameserver))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+// wait blocks until the record is visible on every configured nameserver or
+// the configured timeout expires.
+func (m *dnsWaitForPropagationModel) wait(ctx context.Context, recordType, fqdn, recordData string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var nameservers []string
+	diags.Append(m.Nameservers.ElementsAs(ctx, &nameservers, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	timeout, _ := time.ParseDuration(m.Timeout.ValueString())
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := waitForPropagation(waitCtx, nameservers, recordType, fqdn, recordData); err != nil {
+		diags.AddError(
+			"Error Waiting For AllInkl DNS Propagation",
+			err.Error(),
+		)
+	}
+	return diags
+}
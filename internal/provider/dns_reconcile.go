@@ -0,0 +1,396 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// dnsZoneRecordModel maps an element of the records attribute.
+type dnsZoneRecordModel struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+	Data types.String `tfsdk:"data"`
+	Aux  types.Int64  `tfsdk:"aux"`
+}
+
+// request returns the KAS request creating the record in zoneHost.
+func (m dnsZoneRecordModel) request(zoneHost string) allinkl.DNSRequest {
+	return allinkl.DNSRequest{
+		ZoneHost:   toASCIIHostname(zoneHost),
+		RecordType: m.Type.ValueString(),
+		RecordName: toKASRecordName(m.Name.ValueString()),
+		RecordData: m.Data.ValueString(),
+		RecordAux:  int(m.Aux.ValueInt64()),
+	}
+}
+
+// matches reports whether the remote record holds the same type, name, data
+// and aux, ignoring formatting differences.
+func (m dnsZoneRecordModel) matches(record allinkl.ReturnInfo) bool {
+	return strings.EqualFold(m.Type.ValueString(), record.RecordType) &&
+		hostnameEqual(toKASRecordName(m.Name.ValueString()), record.RecordName) &&
+		recordDataEqual(record.RecordType, m.Data.ValueString(), record.RecordData) &&
+		m.Aux.ValueInt64() == int64(record.RecordAux)
+}
+
+// reconcileDNSRecords deletes the current records that are not desired and
+// adds the desired records that are missing. Nothing is changed if more
+// records would be deleted than maxDeletions allows. Additions go before the
+// remaining deletions, so a failing addition does not leave the zone emptied;
+// only records conflicting with an addition, e.g. the CNAME it replaces, are
+// deleted first.
+func reconcileDNSRecords(ctx context.Context, data *allinklProviderData, zoneHost string, current []allinkl.ReturnInfo, desired []dnsZoneRecordModel, maxDeletions types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var additions []dnsZoneRecordModel
+	for _, record := range desired {
+		if !containsRemoteRecord(current, record) {
+			additions = append(additions, record)
+		}
+	}
+
+	var conflicting, deletions []allinkl.ReturnInfo
+	for _, record := range current {
+		switch {
+		case containsZoneRecord(desired, record):
+		case conflictsWithAddition(record, additions):
+			conflicting = append(conflicting, record)
+		default:
+			deletions = append(deletions, record)
+		}
+	}
+	diags.Append(checkMaxDeletions(zoneHost, maxDeletions, len(conflicting)+len(deletions))...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, record := range conflicting {
+		diags.Append(deleteDNSRecord(ctx, data, record)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, record := range additions {
+		request := record.request(zoneHost)
+		id, err := data.Client.AddDNSSettings(ctx, request)
+		if err != nil {
+			diags.AddError(
+				"Error Creating AllInkl DNS",
+				fmt.Sprintf("Could not create %s record %q in zone %s, unexpected error: %s",
+					request.RecordType, request.RecordName, zoneHost, data.kasErrorMessage(err)),
+			)
+			return diags
+		}
+		logDNSChange(ctx, dnsChange{
+			Action:     dnsChangeCreate,
+			RecordID:   id,
+			ZoneHost:   request.ZoneHost,
+			RecordType: request.RecordType,
+			RecordName: request.RecordName,
+			NewData:    request.RecordData,
+		})
+	}
+
+	for _, record := range deletions {
+		diags.Append(deleteDNSRecord(ctx, data, record)...)
+	}
+
+	return diags
+}
+
+// conflictsWithAddition reports whether the remote record cannot coexist
+// with one of the additions: a CNAME excludes any other record of its name.
+func conflictsWithAddition(record allinkl.ReturnInfo, additions []dnsZoneRecordModel) bool {
+	for _, addition := range additions {
+		if !hostnameEqual(toKASRecordName(addition.Name.ValueString()), record.RecordName) {
+			continue
+		}
+		if strings.EqualFold(record.RecordType, "CNAME") || strings.EqualFold(addition.Type.ValueString(), "CNAME") {
+			return true
+		}
+	}
+	return false
+}
+
+// existingZoneRecords returns the records of the zone reconciling would
+// replace, matched against the planned records so formatting differences do
+// not count as deletions. Resources taking over records on create plan
+// against them as if they were in state.
+func existingZoneRecords(ctx context.Context, data *allinklProviderData, zoneHost string, plan []dnsZoneRecordModel) ([]dnsZoneRecordModel, diag.Diagnostics) {
+	current, diags := readChangeableDNSRecords(ctx, data, zoneHost)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	existing := make([]dnsZoneRecordModel, 0, len(current))
+	for _, record := range current {
+		existing = append(existing, dnsZoneRecordValue(plan, record))
+	}
+	return existing, diags
+}
+
+// existingRecordsWarning returns a warning listing the existing records
+// creating the resource deletes, which the plan itself does not show, or no
+// diagnostics if none are deleted.
+func existingRecordsWarning(zoneHost string, existing, plan []dnsZoneRecordModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var deletions []string
+	for _, record := range existing {
+		if !mayContainZoneRecordModel(plan, record) {
+			deletions = append(deletions, "  - "+describeZoneRecord(record))
+		}
+	}
+	if len(deletions) == 0 {
+		return diags
+	}
+
+	diags.AddWarning(
+		"Existing DNS Records Will Be Deleted",
+		fmt.Sprintf("Creating this resource deletes %d existing records of zone %s that are not configured:\n\n%s\n\n"+
+			"Add them to the configuration to keep them.", len(deletions), zoneHost, strings.Join(deletions, "\n")),
+	)
+	return diags
+}
+
+// maxDeletionsSchemaAttribute returns the schema of the max_deletions
+// attribute of resources reconciling several records.
+func maxDeletionsSchemaAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional: true,
+		MarkdownDescription: "The maximum number of records a single plan or apply may delete, e.g. `0` to only allow additions. " +
+			"Guards against accidentally emptying the zone, e.g. with `records = []`. Unlimited if not set; destroying the resource is not limited.",
+		Validators: []validator.Int64{
+			int64AtLeast(0),
+		},
+	}
+}
+
+// plannedDeletions returns the number of records of state missing from plan.
+// Planned records with unknown values may match any record of their type.
+func plannedDeletions(state, plan []dnsZoneRecordModel) int {
+	deletions := 0
+	for _, record := range state {
+		if !mayContainZoneRecordModel(plan, record) {
+			deletions++
+		}
+	}
+	return deletions
+}
+
+// checkMaxDeletions returns an error if deleting deletions records of the
+// zone exceeds maxDeletions. A null or unknown maxDeletions is unlimited.
+func checkMaxDeletions(zoneHost string, maxDeletions types.Int64, deletions int) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if maxDeletions.IsNull() || maxDeletions.IsUnknown() || int64(deletions) <= maxDeletions.ValueInt64() {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("max_deletions"),
+		"Too Many DNS Record Deletions",
+		fmt.Sprintf("This change deletes %d records of zone %s, but max_deletions allows %d. "+
+			"Check the configured records, or raise max_deletions if the deletions are intended.",
+			deletions, zoneHost, maxDeletions.ValueInt64()),
+	)
+	return diags
+}
+
+// readDNSZoneRecords returns all records of the zone.
+func readDNSZoneRecords(ctx context.Context, data *allinklProviderData, zoneHost string) ([]allinkl.ReturnInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	records, err := data.Client.GetDNSSettings(ctx, toASCIIHostname(zoneHost), "")
+	if err != nil {
+		diags.AddError(
+			"Error Reading AllInkl DNS Zone",
+			"Could not read zone "+zoneHost+": "+data.kasErrorMessage(err),
+		)
+		return nil, diags
+	}
+
+	return records, diags
+}
+
+// readChangeableDNSRecords returns the records of the zone KAS allows to
+// change. Account lease records are left out, as they are not managed by
+// any resource.
+func readChangeableDNSRecords(ctx context.Context, data *allinklProviderData, zoneHost string) ([]allinkl.ReturnInfo, diag.Diagnostics) {
+	records, diags := readDNSZoneRecords(ctx, data, zoneHost)
+	return managedRecords(records), diags
+}
+
+// deleteDNSRecord deletes a remote record, treating already deleted records
+// as success.
+func deleteDNSRecord(ctx context.Context, data *allinklProviderData, record allinkl.ReturnInfo) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	id := fmt.Sprint(record.ID)
+	result, err := data.Client.DeleteDNSSettings(ctx, id)
+	if allinkl.IsNotFound(err) {
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Deleting AllInkl DNS",
+			"Could not delete dns ID "+id+", unexpected error: "+data.kasErrorMessage(err),
+		)
+		return diags
+	}
+	if !result.ReturnInfo {
+		diags.AddError(
+			"Error Deleting AllInkl DNS",
+			"Could not delete dns ID "+id+": KAS reported failure: "+data.kasReturnString(result.ReturnString),
+		)
+		return diags
+	}
+	logDNSChange(ctx, dnsChange{
+		Action:     dnsChangeDelete,
+		RecordID:   id,
+		ZoneHost:   record.ZoneHost,
+		RecordType: record.RecordType,
+		RecordName: record.RecordName,
+		OldData:    record.RecordData,
+	})
+	return diags
+}
+
+// changeableRecords returns the records KAS allows to change.
+func changeableRecords(records []allinkl.ReturnInfo) []allinkl.ReturnInfo {
+	var changeable []allinkl.ReturnInfo
+	for _, record := range records {
+		if record.Changeable == "Y" {
+			changeable = append(changeable, record)
+		}
+	}
+	return changeable
+}
+
+// managedRecords returns the records KAS allows to change without account
+// lease records, which no resource manages. Resources reconciling or
+// refreshing a whole zone must use it, so they neither delete the lease of
+// the run nor report it as drift.
+func managedRecords(records []allinkl.ReturnInfo) []allinkl.ReturnInfo {
+	var managed []allinkl.ReturnInfo
+	for _, record := range changeableRecords(records) {
+		if !isLeaseRecord(record) {
+			managed = append(managed, record)
+		}
+	}
+	return managed
+}
+
+// zoneNameservers returns the nameservers KAS assigned to the zone, taken
+// from the non-changeable NS records of the zone apex, sorted and without
+// trailing dots.
+func zoneNameservers(records []allinkl.ReturnInfo) []string {
+	nameservers := []string{}
+	for _, record := range records {
+		if record.Changeable != "Y" && strings.EqualFold(record.RecordType, "NS") && record.RecordName == "" {
+			nameservers = append(nameservers, normalizeHostname(record.RecordData))
+		}
+	}
+	sort.Strings(nameservers)
+	return nameservers
+}
+
+// zoneNameserversValue returns zoneNameservers as a list value.
+func zoneNameserversValue(records []allinkl.ReturnInfo) types.List {
+	values := []attr.Value{}
+	for _, nameserver := range zoneNameservers(records) {
+		values = append(values, types.StringValue(nameserver))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+func containsZoneRecord(records []dnsZoneRecordModel, record allinkl.ReturnInfo) bool {
+	for _, candidate := range records {
+		if candidate.matches(record) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRemoteRecord(records []allinkl.ReturnInfo, record dnsZoneRecordModel) bool {
+	for _, candidate := range records {
+		if record.matches(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsZoneRecordValue returns the known record matching the remote record, so
+// formatting differences do not show up as changes, or the remote record.
+func dnsZoneRecordValue(known []dnsZoneRecordModel, record allinkl.ReturnInfo) dnsZoneRecordModel {
+	for _, candidate := range known {
+		if candidate.matches(record) {
+			return candidate
+		}
+	}
+
+	return dnsZoneRecordModel{
+		Type: types.StringValue(record.RecordType),
+		Name: types.StringValue(record.RecordName),
+		Data: types.StringValue(record.RecordData),
+		Aux:  types.Int64Value(int64(record.RecordAux)),
+	}
+}
+
+// deletePollInterval is the delay between two checks whether a deleted
+// record is gone.
+var deletePollInterval = 2 * time.Second
+
+// defaultDeleteWaitTimeout bounds waiting for a deleted record to disappear
+// if the delete has no timeout.
+var defaultDeleteWaitTimeout = 5 * time.Minute
+
+// waitForDNSRecordDeleted polls KAS until the record is no longer listed or
+// ctx is done, waiting at most defaultDeleteWaitTimeout if ctx has no
+// deadline.
+func waitForDNSRecordDeleted(ctx context.Context, data *allinklProviderData, zoneHost, recordID string) diag.Diagnostics {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultDeleteWaitTimeout)
+		defer cancel()
+	}
+
+	for {
+		record, diags := readDNSRecord(ctx, data, zoneHost, recordID, nil)
+		if diags.HasError() || record == nil {
+			return diags
+		}
+
+		tflog.Debug(ctx, "Waiting for deleted AllInkl dns record to disappear", map[string]any{
+			"zone_host": zoneHost,
+			"record_id": recordID,
+		})
+
+		timer := time.NewTimer(deletePollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			diags.AddError(
+				"AllInkl DNS Record Still Present",
+				fmt.Sprintf("KAS accepted the delete of dns ID %s in zone %s, but still lists the record: %s. "+
+					"Records created with the same name and type may collide with it until it is gone.", recordID, zoneHost, ctx.Err()),
+			)
+			return diags
+		case <-timer.C:
+		}
+	}
+}
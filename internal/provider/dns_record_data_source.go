@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsRecordDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsRecordDataSource{}
+)
+
+// NewDNSRecordDataSource is a helper function to simplify the provider implementation.
+func NewDNSRecordDataSource() datasource.DataSource {
+	return &dnsRecordDataSource{}
+}
+
+// dnsRecordDataSource is the data source implementation.
+type dnsRecordDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsRecordLookupDataSourceModel maps the data source schema data.
+type dnsRecordLookupDataSourceModel struct {
+	ZoneHost   types.String `tfsdk:"zone_host"`
+	RecordType types.String `tfsdk:"record_type"`
+	RecordName types.String `tfsdk:"record_name"`
+	ID         types.String `tfsdk:"id"`
+	RecordData types.String `tfsdk:"record_data"`
+	RecordAux  types.Int64  `tfsdk:"record_aux"`
+	Changeable types.Bool   `tfsdk:"record_changeable"`
+}
+
+// Metadata returns the data source type name.
+func (d *dnsRecordDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsRecordDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up exactly one record by zone, type and name, e.g. to reference a manually created record. " +
+			"Fails if no or more than one record matches.",
+		Attributes: map[string]schema.Attribute{
+			"zone_host": schema.StringAttribute{
+				Required: true,
+			},
+			"record_type": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringOneOf(supportedRecordTypes...),
+				},
+			},
+			"record_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The NAME of the record, empty or `@` for the zone apex.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The KAS record ID.",
+			},
+			"record_data": schema.StringAttribute{
+				Computed: true,
+			},
+			"record_aux": schema.Int64Attribute{
+				Computed: true,
+			},
+			"record_changeable": schema.BoolAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	var state dnsRecordLookupDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.GetDNSSettings(ctx, toASCIIHostname(state.ZoneHost.ValueString()), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl DNS Zone",
+			"Could not read zone "+state.ZoneHost.ValueString()+": "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	var matches []allinkl.ReturnInfo
+	for _, record := range records {
+		if matchesDNSRecordFilter(record, state.RecordType, state.RecordName) {
+			matches = append(matches, record)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"AllInkl DNS Record Not Found",
+			fmt.Sprintf("Zone %s holds no %s record named %q.", state.ZoneHost.ValueString(), state.RecordType.ValueString(), state.RecordName.ValueString()),
+		)
+		return
+	case 1:
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, record := range matches {
+			ids = append(ids, fmt.Sprint(record.ID))
+		}
+		resp.Diagnostics.AddError(
+			"Ambiguous AllInkl DNS Record",
+			fmt.Sprintf("Zone %s holds %d %s records named %q (IDs %s). Use the allinkl_dns_records data source to list all of them.",
+				state.ZoneHost.ValueString(), len(matches), state.RecordType.ValueString(), state.RecordName.ValueString(), strings.Join(ids, ", ")),
+		)
+		return
+	}
+
+	record := dnsRecordDataSourceValue(matches[0])
+	state.ID = record.ID
+	state.RecordData = record.RecordData
+	state.RecordAux = record.RecordAux
+	state.Changeable = record.Changeable
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *dnsRecordDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
@@ -0,0 +1,430 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &dnsRecordSetResource{}
+	_ resource.ResourceWithConfigure      = &dnsRecordSetResource{}
+	_ resource.ResourceWithImportState    = &dnsRecordSetResource{}
+	_ resource.ResourceWithModifyPlan     = &dnsRecordSetResource{}
+	_ resource.ResourceWithValidateConfig = &dnsRecordSetResource{}
+)
+
+// NewDNSRecordSetResource is a helper function to simplify the provider implementation.
+func NewDNSRecordSetResource() resource.Resource {
+	return &dnsRecordSetResource{}
+}
+
+// dnsRecordSetResource is the resource implementation.
+type dnsRecordSetResource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsRecordSetResourceModel maps the resource schema data.
+type dnsRecordSetResourceModel struct {
+	ID           types.String             `tfsdk:"id"`
+	ZoneHost     types.String             `tfsdk:"zone_host"`
+	RecordType   types.String             `tfsdk:"record_type"`
+	RecordName   types.String             `tfsdk:"record_name"`
+	Values       []dnsRecordSetValueModel `tfsdk:"values"`
+	MaxDeletions types.Int64              `tfsdk:"max_deletions"`
+}
+
+// dnsRecordSetValueModel maps an element of the values attribute.
+type dnsRecordSetValueModel struct {
+	Data types.String `tfsdk:"data"`
+	Aux  types.Int64  `tfsdk:"aux"`
+}
+
+// records returns the values as records of the set.
+func (m dnsRecordSetResourceModel) records() []dnsZoneRecordModel {
+	records := make([]dnsZoneRecordModel, 0, len(m.Values))
+	for _, value := range m.Values {
+		records = append(records, dnsZoneRecordModel{
+			Type: m.RecordType,
+			Name: m.RecordName,
+			Data: value.Data,
+			Aux:  value.Aux,
+		})
+	}
+	return records
+}
+
+// inSet reports whether a remote record belongs to the set.
+func (m dnsRecordSetResourceModel) inSet(record allinkl.ReturnInfo) bool {
+	return strings.EqualFold(m.RecordType.ValueString(), record.RecordType) &&
+		hostnameEqual(toKASRecordName(m.RecordName.ValueString()), record.RecordName)
+}
+
+// Metadata returns the resource type name.
+func (r *dnsRecordSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *dnsRecordSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all records of one name and type, e.g. round-robin A records or several MX targets, as a set. " +
+			"Records of that name and type that are not configured are deleted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_host": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfZoneChanged(),
+				},
+			},
+			"record_type": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringOneOf(supportedRecordTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"record_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "The NAME of the records. Empty or `@` for the zone apex, which is the default.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"values": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The records of the set, at least one. Destroy the resource to delete all records of the set.",
+				Validators: []validator.Set{
+					setSizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"data": schema.StringAttribute{
+							Required: true,
+						},
+						"aux": schema.Int64Attribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(0),
+							MarkdownDescription: "The AUX of the record, e.g. the MX preference. Defaults to `0`.",
+						},
+					},
+				},
+			},
+			"max_deletions": maxDeletionsSchemaAttribute(),
+		},
+	}
+}
+
+// ValidateConfig validates the data of every configured value and checks the
+// values as a whole, e.g. for several CNAME records.
+func (r *dnsRecordSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// values may be unknown until apply when derived from other resources.
+	var values types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("values"), &values)...)
+	if resp.Diagnostics.HasError() || values.IsUnknown() {
+		return
+	}
+
+	var config dnsRecordSetResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.RecordType.IsNull() || config.RecordType.IsUnknown() {
+		return
+	}
+
+	for _, value := range config.Values {
+		if value.Data.IsNull() || value.Data.IsUnknown() {
+			continue
+		}
+		if err := validateRecordData(config.RecordType.ValueString(), value.Data.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("values"),
+				"Invalid "+config.RecordType.ValueString()+" Record Data",
+				err.Error(),
+			)
+		}
+	}
+	resp.Diagnostics.Append(validateZoneRecords(path.Root("values"), config.records())...)
+}
+
+// ModifyPlan warns if the plan creates or deletes NS records and enforces
+// max_deletions. On create, the records of the set already in the zone count
+// as state, as creating deletes those not planned.
+func (r *dnsRecordSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state, plan dnsRecordSetResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if !req.Plan.Raw.IsNull() {
+		// values may be unknown until apply when derived from other resources.
+		var planned types.Set
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("values"), &planned)...)
+		if resp.Diagnostics.HasError() || planned.IsUnknown() {
+			return
+		}
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneHost := plan.ZoneHost.ValueString()
+	if req.Plan.Raw.IsNull() {
+		zoneHost = state.ZoneHost.ValueString()
+	}
+
+	existing := state.records()
+	if req.State.Raw.IsNull() && r.providerData != nil &&
+		!plan.ZoneHost.IsUnknown() && !plan.RecordType.IsUnknown() && !plan.RecordName.IsUnknown() {
+		current, diags := r.readSet(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, record := range current {
+			existing = append(existing, dnsZoneRecordValue(plan.records(), record))
+		}
+		resp.Diagnostics.Append(existingRecordsWarning(zoneHost, existing, plan.records())...)
+	}
+
+	resp.Diagnostics.Append(infrastructureRecordWarning(zoneHost, existing, plan.records())...)
+	resp.Diagnostics.Append(r.providerData.protectInfrastructureRecords(zoneHost, existing, plan.records())...)
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(checkMaxDeletions(zoneHost, plan.MaxDeletions, plannedDeletions(existing, plan.records()))...)
+	}
+}
+
+func (r *dnsRecordSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+// Create reconciles the records of the set with the planned values.
+func (r *dnsRecordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan dnsRecordSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(dnsRecordSetID(plan.ZoneHost.ValueString(), plan.RecordType.ValueString(), plan.RecordName.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the records of the set.
+func (r *dnsRecordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	var state dnsRecordSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := r.readSet(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(current) == 0 {
+		tflog.Warn(ctx, "AllInkl dns record set not found, removing from state", map[string]any{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	known := state.records()
+	values := make([]dnsRecordSetValueModel, 0, len(current))
+	for _, record := range current {
+		value := dnsZoneRecordValue(known, record)
+		values = append(values, dnsRecordSetValueModel{Data: value.Data, Aux: value.Aux})
+	}
+	state.Values = values
+	state.RecordType = types.StringValue(current[0].RecordType)
+	state.RecordName = recordNameValue(state.RecordName, current[0].RecordName)
+	state.ZoneHost = zoneHostValue(state.ZoneHost, current[0].ZoneHost)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update reconciles the records of the set with the planned values.
+func (r *dnsRecordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan dnsRecordSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes every record of the set.
+func (r *dnsRecordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state dnsRecordSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := r.readSet(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, record := range current {
+		resp.Diagnostics.Append(deleteDNSRecord(ctx, r.providerData, record)...)
+	}
+}
+
+// ImportState imports a record set by its `zone_host/record_type/record_name`
+// ID.
+func (r *dnsRecordSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	lookup, err := parseDNSLookupImportID(req.ID)
+	if err == nil && lookup.RecordData != nil {
+		err = fmt.Errorf("expected import ID in the format `zone_host/record_type/record_name`, got: %q", req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(verifyImportedDNSZone(ctx, r.providerData, req.ID, lookup.ZoneHost)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dnsRecordSetID(lookup.ZoneHost, lookup.RecordType, lookup.RecordName))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_host"), lookup.ZoneHost)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("record_type"), lookup.RecordType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("record_name"), lookup.RecordName)...)
+}
+
+// reconcile makes the records of the set match the values of plan.
+func (r *dnsRecordSetResource) reconcile(ctx context.Context, plan dnsRecordSetResourceModel) diag.Diagnostics {
+	current, diags := r.readSet(ctx, plan)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(reconcileDNSRecords(ctx, r.providerData, plan.ZoneHost.ValueString(), current, plan.records(), plan.MaxDeletions)...)
+	return diags
+}
+
+// readSet returns the changeable remote records of the set.
+func (r *dnsRecordSetResource) readSet(ctx context.Context, m dnsRecordSetResourceModel) ([]allinkl.ReturnInfo, diag.Diagnostics) {
+	records, diags := readChangeableDNSRecords(ctx, r.providerData, m.ZoneHost.ValueString())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var set []allinkl.ReturnInfo
+	for _, record := range records {
+		if m.inSet(record) {
+			set = append(set, record)
+		}
+	}
+	return set, diags
+}
+
+// dnsRecordSetID returns the `zone_host/record_type/record_name` ID of a
+// record set.
+func dnsRecordSetID(zoneHost, recordType, recordName string) string {
+	return formatDNSImportID(zoneHost, strings.ToUpper(recordType)) + "/" + recordName
+}
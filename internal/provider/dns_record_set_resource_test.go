@@ -0,0 +1,101 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDNSRecordSetResource(t *testing.T) {
+	server := newServer(t)
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "www", Type: "A", Data: "192.0.2.1", Changeable: true})
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "www", Type: "A", Data: "192.0.2.9", Changeable: true})
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "api", Type: "A", Data: "192.0.2.9", Changeable: true})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_dns_record_set" "www" {
+  zone_host   = "example.com"
+  record_type = "A"
+  record_name = "www"
+  values      = [{ data = "192.0.2.1" }, { data = "192.0.2.2" }]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("allinkl_dns_record_set.www", "id", "example.com/A/www"),
+					resource.TestCheckResourceAttr("allinkl_dns_record_set.www", "values.#", "2"),
+					checkRecords(server, "example.com", "A www 192.0.2.1", "A www 192.0.2.2", "A api 192.0.2.9"),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_dns_record_set" "www" {
+  zone_host   = "example.com"
+  record_type = "A"
+  record_name = "www"
+  values      = [{ data = "192.0.2.3" }]
+}
+`,
+				Check: checkRecords(server, "example.com", "A www 192.0.2.3", "A api 192.0.2.9"),
+			},
+			{
+				ResourceName:            "allinkl_dns_record_set.www",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"max_deletions"},
+			},
+		},
+		CheckDestroy: checkRecords(server, "example.com", "A api 192.0.2.9"),
+	})
+}
+
+func TestDNSRecordSetResourceCreateMaxDeletions(t *testing.T) {
+	server := newServer(t)
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "www", Type: "A", Data: "192.0.2.9", Changeable: true})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_dns_record_set" "www" {
+  zone_host     = "example.com"
+  record_type   = "A"
+  record_name   = "www"
+  values        = [{ data = "192.0.2.1" }]
+  max_deletions = 0
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`This change deletes 1 records of zone example.com`),
+			},
+		},
+	})
+}
+
+func TestDNSRecordSetResourceRequiresValues(t *testing.T) {
+	server := newServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_dns_record_set" "www" {
+  zone_host   = "example.com"
+  record_type = "A"
+  record_name = "www"
+  values      = []
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`set must contain at least 1 elements`),
+			},
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package provider
+
+// supportedRecordTypes lists the resource record types the KAS API accepts
+// for add_dns_settings and update_dns_settings.
+var supportedRecordTypes = []string{
+	"A",
+	"AAAA",
+	"CAA",
+	"CNAME",
+	"MX",
+	"NS",
+	"SRV",
+	"TLSA",
+	"TXT",
+}
+
+// auxRecordTypes lists the record types whose record_aux carries the record
+// priority. KAS ignores record_aux for every other type.
+var auxRecordTypes = map[string]bool{
+	"MX":  true,
+	"SRV": true,
+}
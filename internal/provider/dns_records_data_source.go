@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsRecordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsRecordsDataSource{}
+)
+
+// NewDNSRecordsDataSource is a helper function to simplify the provider implementation.
+func NewDNSRecordsDataSource() datasource.DataSource {
+	return &dnsRecordsDataSource{}
+}
+
+// dnsRecordsDataSource is the data source implementation.
+type dnsRecordsDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsRecordsDataSourceModel maps the data source schema data.
+type dnsRecordsDataSourceModel struct {
+	ZoneHost   types.String               `tfsdk:"zone_host"`
+	RecordType types.String               `tfsdk:"record_type"`
+	RecordName types.String               `tfsdk:"record_name"`
+	Records    []dnsRecordDataSourceModel `tfsdk:"records"`
+}
+
+// dnsRecordDataSourceModel maps a record returned by the DNS data sources.
+type dnsRecordDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	RecordType types.String `tfsdk:"record_type"`
+	RecordName types.String `tfsdk:"record_name"`
+	RecordData types.String `tfsdk:"record_data"`
+	RecordAux  types.Int64  `tfsdk:"record_aux"`
+	Changeable types.Bool   `tfsdk:"record_changeable"`
+}
+
+// dnsRecordDataSourceValue converts a remote record.
+func dnsRecordDataSourceValue(record allinkl.ReturnInfo) dnsRecordDataSourceModel {
+	return dnsRecordDataSourceModel{
+		ID:         types.StringValue(fmt.Sprint(record.ID)),
+		RecordType: types.StringValue(record.RecordType),
+		RecordName: types.StringValue(record.RecordName),
+		RecordData: types.StringValue(record.RecordData),
+		RecordAux:  types.Int64Value(int64(record.RecordAux)),
+		Changeable: types.BoolValue(record.Changeable == "Y"),
+	}
+}
+
+// matchesDNSRecordFilter reports whether record has the given type and name.
+// Null filters match every record.
+func matchesDNSRecordFilter(record allinkl.ReturnInfo, recordType, recordName types.String) bool {
+	if !recordType.IsNull() && !strings.EqualFold(recordType.ValueString(), record.RecordType) {
+		return false
+	}
+	if !recordName.IsNull() && !hostnameEqual(toKASRecordName(recordName.ValueString()), record.RecordName) {
+		return false
+	}
+	return true
+}
+
+// Metadata returns the data source type name.
+func (d *dnsRecordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_records"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsRecordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the records of a zone, optionally filtered by type and name, to reference existing records without importing them.",
+		Attributes: map[string]schema.Attribute{
+			"zone_host": schema.StringAttribute{
+				Required: true,
+			},
+			"record_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return records of this type.",
+				Validators: []validator.String{
+					stringOneOf(supportedRecordTypes...),
+				},
+			},
+			"record_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return records of this name, empty or `@` for the zone apex.",
+			},
+			"records": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dnsRecordDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// dnsRecordDataSourceAttributes returns the attributes of a record returned
+// by the DNS data sources.
+func dnsRecordDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The KAS record ID.",
+		},
+		"record_type": schema.StringAttribute{
+			Computed: true,
+		},
+		"record_name": schema.StringAttribute{
+			Computed: true,
+		},
+		"record_data": schema.StringAttribute{
+			Computed: true,
+		},
+		"record_aux": schema.Int64Attribute{
+			Computed: true,
+		},
+		"record_changeable": schema.BoolAttribute{
+			Computed: true,
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	var state dnsRecordsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.GetDNSSettings(ctx, toASCIIHostname(state.ZoneHost.ValueString()), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl DNS Zone",
+			"Could not read zone "+state.ZoneHost.ValueString()+": "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	state.Records = []dnsRecordDataSourceModel{}
+	for _, record := range records {
+		if matchesDNSRecordFilter(record, state.RecordType, state.RecordName) {
+			state.Records = append(state.Records, dnsRecordDataSourceValue(record))
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *dnsRecordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsReverseLookupDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsReverseLookupDataSource{}
+)
+
+// NewDNSReverseLookupDataSource is a helper function to simplify the provider implementation.
+func NewDNSReverseLookupDataSource() datasource.DataSource {
+	return &dnsReverseLookupDataSource{}
+}
+
+// dnsReverseLookupDataSource is the data source implementation.
+type dnsReverseLookupDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsReverseLookupDataSourceModel maps the data source schema data.
+type dnsReverseLookupDataSourceModel struct {
+	ZoneHost   types.String               `tfsdk:"zone_host"`
+	RecordData types.String               `tfsdk:"record_data"`
+	Records    []dnsRecordDataSourceModel `tfsdk:"records"`
+	FQDNs      []types.String             `tfsdk:"fqdns"`
+}
+
+// Metadata returns the data source type name.
+func (d *dnsReverseLookupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_reverse_lookup"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsReverseLookupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the records of a zone pointing at a value, e.g. every name resolving to a server before decommissioning it.",
+		Attributes: map[string]schema.Attribute{
+			"zone_host": schema.StringAttribute{
+				Required: true,
+			},
+			"record_data": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "The value to look up, e.g. an IP address or hostname. Compared like `record_data` of `allinkl_dns_record`, " +
+					"so differences in case, trailing dots, TXT quoting and IPv6 spelling are ignored.",
+			},
+			"records": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: dnsRecordDataSourceAttributes(),
+				},
+			},
+			"fqdns": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The fully qualified names of the matching records, without duplicates.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsReverseLookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	var state dnsReverseLookupDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.GetDNSSettings(ctx, toASCIIHostname(state.ZoneHost.ValueString()), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl DNS Zone",
+			"Could not read zone "+state.ZoneHost.ValueString()+": "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	state.Records = []dnsRecordDataSourceModel{}
+	state.FQDNs = []types.String{}
+	seen := map[string]bool{}
+	for _, record := range records {
+		if !recordDataEqual(record.RecordType, record.RecordData, state.RecordData.ValueString()) {
+			continue
+		}
+		state.Records = append(state.Records, dnsRecordDataSourceValue(record))
+
+		fqdn := recordFQDN(record.RecordName, record.ZoneHost)
+		if !seen[fqdn] {
+			seen[fqdn] = true
+			state.FQDNs = append(state.FQDNs, types.StringValue(fqdn))
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *dnsReverseLookupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
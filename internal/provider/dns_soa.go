@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// soaQueryTimeout bounds a single SOA query.
+const soaQueryTimeout = 5 * time.Second
+
+// querySOA asks nameserver for the SOA record of zone and returns its serial
+// and the TTL it is served with. The answer must be authoritative, so a
+// nameserver the zone is not delegated to is reported as an error.
+func querySOA(ctx context.Context, nameserver, zone string) (serial, ttl uint32, err error) {
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		nameserver = net.JoinHostPort(nameserver, "53")
+	}
+
+	name, err := dnsmessage.NewName(strings.TrimSuffix(toASCIIHostname(zone), ".") + ".")
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid zone %q: %w", zone, err)
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return 0, 0, err
+	}
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: binary.BigEndian.Uint16(id[:])},
+		Questions: []dnsmessage.Question{
+			{Name: name, Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET},
+		},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, soaQueryTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", nameserver)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write(packed); err != nil {
+		return 0, 0, err
+	}
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		var response dnsmessage.Message
+		if err := response.Unpack(buf[:n]); err != nil || response.ID != query.ID || !response.Response {
+			continue
+		}
+		if response.RCode != dnsmessage.RCodeSuccess {
+			return 0, 0, fmt.Errorf("%s answered %s", nameserver, response.RCode)
+		}
+		if !response.Authoritative {
+			return 0, 0, fmt.Errorf("%s is not authoritative for %s", nameserver, zone)
+		}
+		for _, answer := range response.Answers {
+			if soa, ok := answer.Body.(*dnsmessage.SOAResource); ok {
+				return soa.Serial, answer.Header.TTL, nil
+			}
+		}
+		return 0, 0, fmt.Errorf("%s returned no SOA record for %s", nameserver, zone)
+	}
+}
+
+// checkZoneSOA queries every nameserver for the SOA serial of zone and
+// returns the highest serial served along with the TTL of that SOA, which KAS
+// applies to every record of the zone. Unreachable or non-authoritative
+// nameservers and diverging serials are reported as warnings.
+func checkZoneSOA(ctx context.Context, nameservers []string, zone string) (types.Int64, types.Int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	serials := make(map[string]uint32, len(nameservers))
+	var highest, highestTTL uint32
+	for _, nameserver := range nameservers {
+		serial, ttl, err := querySOA(ctx, nameserver, zone)
+		if err != nil {
+			diags.AddWarning(
+				"AllInkl DNS Zone Not Served",
+				fmt.Sprintf("Could not query the SOA of %s from %s: %s. Check the delegation of the zone.", zone, nameserver, err),
+			)
+			continue
+		}
+		serials[nameserver] = serial
+		if len(serials) == 1 || serial > highest {
+			highest, highestTTL = serial, ttl
+		}
+	}
+
+	if len(serials) == 0 {
+		return types.Int64Null(), types.Int64Null(), diags
+	}
+
+	for nameserver, serial := range serials {
+		if serial != highest {
+			diags.AddWarning(
+				"AllInkl DNS Zone Serial Mismatch",
+				fmt.Sprintf("%s serves serial %d for %s, other nameservers serve %d.", nameserver, serial, zone, highest),
+			)
+		}
+	}
+
+	return types.Int64Value(int64(highest)), types.Int64Value(int64(highestTTL)), diags
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// dnsSRVModel maps the structured SRV attributes. The SRV priority is kept in
+// record_aux.
+type dnsSRVModel struct {
+	Weight types.Int64  `tfsdk:"weight"`
+	Port   types.Int64  `tfsdk:"port"`
+	Target types.String `tfsdk:"target"`
+}
+
+// srvSchemaAttribute returns the schema of the srv attribute.
+func srvSchemaAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Structured SRV record data, composed into `record_data` as `<weight> <port> <target>`. " +
+			"The priority is set with `record_aux`. Conflicts with `record_data`.",
+		Attributes: map[string]schema.Attribute{
+			"weight": schema.Int64Attribute{
+				Required: true,
+			},
+			"port": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The port of the service, `0` together with the target `.` if the service is not available (RFC 2782).",
+			},
+			"target": schema.StringAttribute{
+				Required: true,
+			},
+		},
+	}
+}
+
+// isKnown reports whether every SRV field is known.
+func (m *dnsSRVModel) isKnown() bool {
+	return !m.Weight.IsUnknown() && !m.Port.IsUnknown() && !m.Target.IsUnknown()
+}
+
+// recordData composes the KAS record_data of the SRV record.
+func (m *dnsSRVModel) recordData() string {
+	return fmt.Sprintf("%d %d %s", m.Weight.ValueInt64(), m.Port.ValueInt64(), m.Target.ValueString())
+}
+
+// validate checks the SRV fields are within their allowed ranges.
+func (m *dnsSRVModel) validate() error {
+	if !m.Weight.IsUnknown() && (m.Weight.ValueInt64() < 0 || m.Weight.ValueInt64() > 65535) {
+		return fmt.Errorf("weight must be between 0 and 65535, got %d", m.Weight.ValueInt64())
+	}
+	if !m.Port.IsUnknown() && (m.Port.ValueInt64() < 0 || m.Port.ValueInt64() > 65535) {
+		return fmt.Errorf("port must be between 0 and 65535, got %d", m.Port.ValueInt64())
+	}
+	if !m.Target.IsUnknown() && strings.TrimSpace(m.Target.ValueString()) == "" {
+		return fmt.Errorf("target must not be empty")
+	}
+	return nil
+}
+
+// parseSRVRecordData decomposes KAS SRV record_data "<weight> <port> <target>".
+func parseSRVRecordData(data string) (*dnsSRVModel, error) {
+	fields := strings.Fields(data)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("expected \"<weight> <port> <target>\", got %q", data)
+	}
+
+	weight, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid weight %q: %w", fields[0], err)
+	}
+	port, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %w", fields[1], err)
+	}
+
+	srv := &dnsSRVModel{
+		Weight: types.Int64Value(weight),
+		Port:   types.Int64Value(port),
+		Target: types.StringValue(fields[2]),
+	}
+	return srv, srv.validate()
+}
+
+// validateSRVRecordData validates SRV record data given as a plain string.
+func validateSRVRecordData(data string) error {
+	_, err := parseSRVRecordData(data)
+	return err
+}
+
+// srvValue refreshes the structured SRV attributes from remote record data.
+// Records managed through record_data keep a null srv attribute.
+func srvValue(known *dnsSRVModel, remote string) *dnsSRVModel {
+	if known == nil {
+		return nil
+	}
+
+	srv, err := parseSRVRecordData(remote)
+	if err != nil {
+		return known
+	}
+	if hostnameEqual(known.Target.ValueString(), srv.Target.ValueString()) {
+		srv.Target = known.Target
+	}
+	return srv
+}
@@ -0,0 +1,30 @@
+package provider
+
+import "testing"
+
+func TestParseSRVRecordData(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		data      string
+		wantError bool
+	}{
+		"service":       {data: "60 5060 sip.example.net."},
+		"not-available": {data: "0 0 ."},
+		"max-port":      {data: "0 65535 sip.example.net."},
+		"port-too-high": {data: "0 65536 sip.example.net.", wantError: true},
+		"negative-port": {data: "0 -1 sip.example.net.", wantError: true},
+		"missing-field": {data: "0 5060", wantError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseSRVRecordData(testCase.data)
+			if (err != nil) != testCase.wantError {
+				t.Errorf("parseSRVRecordData(%q) error = %v, want error %t", testCase.data, err, testCase.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// dnsResourceSchemaVersion is the current version of the allinkl_dns schema.
+// Bump it together with a new entry in UpgradeState whenever a change to the
+// schema needs existing state to be rewritten.
+//
+//   - 0: initial schema, record_aux required.
+//   - 1: record_aux optional, defaulting to 0.
+//   - 2: last_updated in RFC 3339 instead of RFC 850 format.
+const dnsResourceSchemaVersion = 2
+
+// UpgradeState upgrades allinkl_dns state written by earlier schema versions
+// to the current one.
+func (r *dnsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := dnsResourceSchemaV0(ctx, r)
+	schemaV1 := dnsResourceSchemaV1(ctx, r)
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeDNSResourceStateV0,
+		},
+		1: {
+			PriorSchema:   &schemaV1,
+			StateUpgrader: upgradeDNSResourceStateV1,
+		},
+	}
+}
+
+// dnsResourceSchemaV1 returns the version 1 schema. Its attributes match the
+// current schema; only the format of last_updated differs. Attributes added
+// without a version bump are read as null from older state.
+func dnsResourceSchemaV1(ctx context.Context, r *dnsResource) schema.Schema {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	schemaV1 := resp.Schema
+	schemaV1.Version = 1
+	return schemaV1
+}
+
+// dnsResourceSchemaV0 returns the version 0 schema. It only differs from the
+// version 1 schema in record_aux being required.
+func dnsResourceSchemaV0(ctx context.Context, r *dnsResource) schema.Schema {
+	schemaV0 := dnsResourceSchemaV1(ctx, r)
+	schemaV0.Version = 0
+	schemaV0.Attributes["record_aux"] = schema.Int64Attribute{
+		Required: true,
+	}
+	return schemaV0
+}
+
+// upgradeDNSResourceStateV0 upgrades version 0 state, filling in the
+// record_aux default for state that somehow lacks it.
+func upgradeDNSResourceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var state dnsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgradeDNSResourceModel(state, 0))...)
+}
+
+// upgradeDNSResourceStateV1 upgrades version 1 state, converting
+// last_updated to RFC 3339.
+func upgradeDNSResourceStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var state dnsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgradeDNSResourceModel(state, 1))...)
+}
+
+// upgradeDNSResourceModel applies the changes of every schema version after
+// version to state read with the schema of version.
+func upgradeDNSResourceModel(state dnsResourceModel, version int64) dnsResourceModel {
+	if version < 1 && state.RecordAux.IsNull() {
+		state.RecordAux = types.Int64Value(0)
+	}
+	if version < 2 {
+		state.LastUpdated = upgradeLastUpdated(state.LastUpdated)
+	}
+	return state
+}
+
+// upgradeLastUpdated converts a last_updated value in RFC 850 format, as
+// written before schema version 2, to RFC 3339. Values that do not parse are
+// dropped, as they carry no information the provider relies on.
+func upgradeLastUpdated(lastUpdated types.String) types.String {
+	if lastUpdated.IsNull() || lastUpdated.IsUnknown() {
+		return lastUpdated
+	}
+
+	t, err := time.Parse(time.RFC850, lastUpdated.ValueString())
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
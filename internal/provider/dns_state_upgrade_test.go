@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDNSResourceUpgradeStateV0(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// State as written by the initial schema, without any of the attributes
+	// added since.
+	resp, err := server.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "allinkl_dns",
+		Version:  0,
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{"id":"12345","last_updated":"Monday, 02-Jan-06 15:04:05 UTC","zone_host":"example.com","record_type":"MX","record_name":"","record_data":"mail.example.com.","record_aux":10}`),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resourceSchema := schemaResp.ResourceSchemas["allinkl_dns"]
+	if resourceSchema.Version != dnsResourceSchemaVersion {
+		t.Fatalf("expected schema version %d, got %d", dnsResourceSchemaVersion, resourceSchema.Version)
+	}
+
+	state, err := resp.UpgradedState.Unmarshal(resourceSchema.ValueType())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var id, recordData, lastUpdated string
+	if err := attributes["id"].As(&id); err != nil || id != "12345" {
+		t.Errorf("expected id %q, got %q (%v)", "12345", id, err)
+	}
+	if err := attributes["record_data"].As(&recordData); err != nil || recordData != "mail.example.com." {
+		t.Errorf("expected record_data %q, got %q (%v)", "mail.example.com.", recordData, err)
+	}
+	if err := attributes["last_updated"].As(&lastUpdated); err != nil || lastUpdated != "2006-01-02T15:04:05Z" {
+		t.Errorf("expected last_updated %q, got %q (%v)", "2006-01-02T15:04:05Z", lastUpdated, err)
+	}
+	if !attributes["create_only"].IsNull() {
+		t.Errorf("expected create_only to be null, got %s", attributes["create_only"])
+	}
+}
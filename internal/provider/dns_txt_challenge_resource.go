@@ -0,0 +1,417 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// acmeChallengeRecordName is the record name used by the ACME DNS-01
+// challenge.
+const acmeChallengeRecordName = "_acme-challenge"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &dnsTXTChallengeResource{}
+	_ resource.ResourceWithConfigure   = &dnsTXTChallengeResource{}
+	_ resource.ResourceWithImportState = &dnsTXTChallengeResource{}
+	_ resource.ResourceWithModifyPlan  = &dnsTXTChallengeResource{}
+)
+
+// NewDNSTXTChallengeResource is a helper function to simplify the provider implementation.
+func NewDNSTXTChallengeResource() resource.Resource {
+	return &dnsTXTChallengeResource{}
+}
+
+// dnsTXTChallengeResource is the resource implementation.
+type dnsTXTChallengeResource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsTXTChallengeResourceModel maps the resource schema data.
+type dnsTXTChallengeResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ZoneHost           types.String `tfsdk:"zone_host"`
+	RecordName         types.String `tfsdk:"record_name"`
+	Value              types.String `tfsdk:"value"`
+	FQDN               types.String `tfsdk:"fqdn"`
+	WaitForPropagation types.Bool   `tfsdk:"wait_for_propagation"`
+	PropagationTimeout types.String `tfsdk:"propagation_timeout"`
+	Nameservers        types.List   `tfsdk:"nameservers"`
+	ExpiresAfter       types.String `tfsdk:"expires_after"`
+	ExpiresAt          types.String `tfsdk:"expires_at"`
+	Expired            types.Bool   `tfsdk:"expired"`
+}
+
+// zoneRecords returns the challenge as a zone record.
+func (m dnsTXTChallengeResourceModel) zoneRecords() []dnsZoneRecordModel {
+	return []dnsZoneRecordModel{{
+		Type: types.StringValue("TXT"),
+		Name: m.RecordName,
+		Data: m.Value,
+		Aux:  types.Int64Value(0),
+	}}
+}
+
+// Metadata returns the resource type name.
+func (r *dnsTXTChallengeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_txt_challenge"
+}
+
+// Schema defines the schema for the resource.
+func (r *dnsTXTChallengeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the `_acme-challenge` TXT record of an ACME DNS-01 challenge.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_host": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfZoneChanged(),
+				},
+			},
+			"record_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(acmeChallengeRecordName),
+				MarkdownDescription: "Name of the TXT record relative to the zone, e.g. `_acme-challenge.www`. Defaults to `_acme-challenge`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The key authorization digest provided by the ACME server.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"wait_for_propagation": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Wait until every nameserver in `nameservers` serves the record before finishing create.",
+			},
+			"propagation_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("5m"),
+				MarkdownDescription: "Maximum time to wait for propagation. Defaults to `5m`.",
+				Validators: []validator.String{
+					duration(),
+				},
+			},
+			"nameservers": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(defaultNameserversValue()),
+				MarkdownDescription: "Nameservers queried while waiting for propagation. Defaults to the All-Inkl nameservers.",
+			},
+			"expires_after": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Duration after creation when the challenge record is no longer needed. " +
+					"Once expired, the next plan deletes the record from the zone and marks the resource as `expired`.",
+				Validators: []validator.String{
+					duration(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expired": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the challenge record expired and was deleted from the zone.",
+			},
+		},
+	}
+}
+
+func (r *dnsTXTChallengeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+// ModifyPlan plans the deletion of the challenge record once it expired.
+func (r *dnsTXTChallengeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expired"), false)...)
+		return
+	}
+
+	var state dnsTXTChallengeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expired := state.Expired.ValueBool()
+	if !expired && state.ExpiresAt.ValueString() != "" {
+		expiresAt, err := time.Parse(time.RFC3339, state.ExpiresAt.ValueString())
+		expired = err == nil && time.Now().After(expiresAt)
+	}
+
+	if expired && !state.Expired.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"ACME Challenge Record Expired",
+			fmt.Sprintf("The challenge record %s expired at %s and will be deleted from the zone.", state.FQDN.ValueString(), state.ExpiresAt.ValueString()),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expired"), expired)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *dnsTXTChallengeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan dnsTXTChallengeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := r.client.AddDNSSettings(ctx, allinkl.DNSRequest{
+		ZoneHost:   toASCIIHostname(plan.ZoneHost.ValueString()),
+		RecordType: "TXT",
+		RecordName: toKASRecordName(plan.RecordName.ValueString()),
+		RecordData: plan.Value.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating AllInkl ACME Challenge",
+			"Could not create challenge record, unexpected error: "+r.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	now := time.Now()
+	plan.ID = types.StringValue(id)
+	plan.FQDN = types.StringValue(recordFQDN(plan.RecordName.ValueString(), plan.ZoneHost.ValueString()))
+	plan.Expired = types.BoolValue(false)
+	plan.ExpiresAt = types.StringNull()
+	if !plan.ExpiresAfter.IsNull() {
+		expiresAfter, _ := time.ParseDuration(plan.ExpiresAfter.ValueString())
+		plan.ExpiresAt = types.StringValue(now.Add(expiresAfter).UTC().Format(time.RFC3339))
+	}
+
+	// Set state before waiting so the record is tracked even if the wait fails.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.WaitForPropagation.ValueBool() {
+		return
+	}
+
+	wait := dnsWaitForPropagationModel{
+		Nameservers: plan.Nameservers,
+		Timeout:     plan.PropagationTimeout,
+	}
+	resp.Diagnostics.Append(wait.wait(ctx, "TXT", plan.FQDN.ValueString(), plan.Value.ValueString())...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *dnsTXTChallengeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	// Get current state
+	var state dnsTXTChallengeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Expired challenges no longer exist remotely.
+	if state.Expired.ValueBool() {
+		return
+	}
+
+	record, diags := readDNSRecord(ctx, r.providerData, state.ZoneHost.ValueString(), state.ID.ValueString(), state.zoneRecords())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if record == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ZoneHost = zoneHostValue(state.ZoneHost, record.ZoneHost)
+	state.RecordName = recordNameValue(state.RecordName, record.RecordName)
+	state.Value = recordDataValue("TXT", state.Value, record.RecordData)
+	state.FQDN = types.StringValue(recordFQDN(record.RecordName, state.ZoneHost.ValueString()))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update deletes the challenge record once it expired. Every other change
+// forces replacement.
+func (r *dnsTXTChallengeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan, state dnsTXTChallengeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Expired.ValueBool() && !state.Expired.ValueBool() {
+		resp.Diagnostics.Append(r.deleteRecord(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dnsTXTChallengeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state dnsTXTChallengeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Expired.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.deleteRecord(ctx, state)...)
+}
+
+// deleteRecord removes the challenge record from the zone.
+func (r *dnsTXTChallengeResource) deleteRecord(ctx context.Context, state dnsTXTChallengeResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	result, err := r.client.DeleteDNSSettings(ctx, state.ID.ValueString())
+	if allinkl.IsNotFound(err) {
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Deleting AllInkl ACME Challenge",
+			"Could not delete challenge record "+state.ID.ValueString()+", unexpected error: "+r.providerData.kasErrorMessage(err),
+		)
+		return diags
+	}
+	if !result.ReturnInfo {
+		diags.AddError(
+			"Error Deleting AllInkl ACME Challenge",
+			"Could not delete challenge record "+state.ID.ValueString()+": KAS reported failure: "+r.providerData.kasReturnString(result.ReturnString),
+		)
+	}
+	return diags
+}
+
+// ImportState imports a challenge record by its `zone_host/record_id` ID.
+func (r *dnsTXTChallengeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	zoneHost, recordID, err := parseDNSImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(verifyImportedDNSRecord(ctx, r.providerData, req.ID, zoneHost, recordID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_host"), zoneHost)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), recordID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("expired"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_propagation"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("propagation_timeout"), "5m")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("nameservers"), defaultNameservers)...)
+}
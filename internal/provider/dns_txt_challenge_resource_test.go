@@ -0,0 +1,123 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/ViMaSter/terraform-provider-allinkl/internal/provider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestDNSTXTChallengeResourceWaitsForPropagation(t *testing.T) {
+	server := newServer(t)
+	defer provider.SetPropagationPollInterval(100 * time.Millisecond)()
+
+	lag, waited := lagDNS(server, time.Second)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: lag,
+				Config: server.ProviderConfig() + fmt.Sprintf(`
+resource "allinkl_dns_txt_challenge" "www" {
+  zone_host            = "example.com"
+  record_name          = "_acme-challenge.www"
+  value                = "token"
+  wait_for_propagation = true
+  nameservers          = [%q]
+}
+`, server.DNSAddress()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("allinkl_dns_txt_challenge.www", "fqdn", "_acme-challenge.www.example.com"),
+					checkRecords(server, "example.com", "TXT _acme-challenge.www token"),
+					waited,
+				),
+			},
+		},
+		CheckDestroy: checkRecords(server, "example.com"),
+	})
+}
+
+func TestDNSTXTChallengeResourcePropagationTimeout(t *testing.T) {
+	server := newServer(t)
+	defer provider.SetPropagationPollInterval(100 * time.Millisecond)()
+	server.SetDNSLag(true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + fmt.Sprintf(`
+resource "allinkl_dns_txt_challenge" "apex" {
+  zone_host            = "example.com"
+  value                = "token"
+  wait_for_propagation = true
+  propagation_timeout  = "1s"
+  nameservers          = [%q]
+}
+`, server.DNSAddress()),
+				ExpectError: regexp.MustCompile(`TXT record _acme-challenge.example.com not visible on`),
+			},
+		},
+		// The record created before the wait failed is tracked and destroyed.
+		CheckDestroy: checkRecords(server, "example.com"),
+	})
+}
+
+func TestDNSTXTChallengeResourceExpiry(t *testing.T) {
+	server := newServer(t)
+
+	// Long enough for the first step to finish before the challenge expires.
+	const expiresAfter = 4 * time.Second
+	config := server.ProviderConfig() + fmt.Sprintf(`
+resource "allinkl_dns_txt_challenge" "apex" {
+  zone_host     = "example.com"
+  value         = "token"
+  expires_after = %q
+}
+`, expiresAfter)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("allinkl_dns_txt_challenge.apex", "expired", "false"),
+					resource.TestCheckResourceAttrSet("allinkl_dns_txt_challenge.apex", "expires_at"),
+					checkRecords(server, "example.com", "TXT _acme-challenge token"),
+				),
+			},
+			{
+				// Once expired, the plan updates the challenge in place, which
+				// deletes the record but keeps the resource.
+				PreConfig: func() { time.Sleep(expiresAfter) },
+				Config:    config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("allinkl_dns_txt_challenge.apex", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("allinkl_dns_txt_challenge.apex", "expired", "true"),
+					checkRecords(server, "example.com"),
+				),
+			},
+			{
+				// Refreshing an expired challenge keeps it in state although
+				// its record is gone.
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("allinkl_dns_txt_challenge.apex", "expired", "true"),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+		CheckDestroy: checkRecords(server, "example.com"),
+	})
+}
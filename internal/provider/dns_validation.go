@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validateARecordData validates that data is an IPv4 address.
+func validateARecordData(data string) error {
+	if err := rejectWhitespace("A", data); err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(data)
+	if err != nil || !addr.Is4() {
+		return fmt.Errorf("A records expect an IPv4 address, got %q", data)
+	}
+	return nil
+}
+
+// validateAAAARecordData validates that data is an IPv6 address.
+func validateAAAARecordData(data string) error {
+	if err := rejectWhitespace("AAAA", data); err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(data)
+	if err != nil || !addr.Is6() || addr.Zone() != "" {
+		return fmt.Errorf("AAAA records expect an IPv6 address, got %q", data)
+	}
+	return nil
+}
+
+// validateHostnameRecordData validates that data is a single hostname, such
+// as the target of a CNAME, MX or NS record. Internationalized names are
+// accepted; underscores are allowed as they appear in service names.
+func validateHostnameRecordData(recordType, data string) error {
+	if err := rejectWhitespace(recordType, data); err != nil {
+		return err
+	}
+
+	name := strings.TrimSuffix(toASCIIHostname(data), ".")
+	if name == "" {
+		return fmt.Errorf("%s records expect a hostname, got %q", recordType, data)
+	}
+	if len(name) > 253 {
+		return fmt.Errorf("%s records expect a hostname of at most 253 characters, got %d", recordType, len(name))
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return fmt.Errorf("%s records expect a hostname without empty labels, got %q", recordType, data)
+		}
+		if len(label) > 63 {
+			return fmt.Errorf("%s records expect hostname labels of at most 63 characters, got %q", recordType, label)
+		}
+		for _, c := range label {
+			if !isHostnameRune(c) {
+				return fmt.Errorf("%s records expect a hostname, got %q containing %q", recordType, data, c)
+			}
+		}
+	}
+	return nil
+}
+
+// isHostnameRune reports whether c may appear in a label of an ASCII
+// hostname.
+func isHostnameRune(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_'
+}
+
+// rejectWhitespace returns an error if data contains whitespace, which
+// usually is a copy-paste error in record types holding a single value.
+func rejectWhitespace(recordType, data string) error {
+	if strings.IndexFunc(data, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%s records expect a single value without whitespace, got %q", recordType, data)
+	}
+	return nil
+}
+
+// validateTLSARecordData validates TLSA record data of the form
+// "<usage> <selector> <matching-type> <certificate-association-data>".
+func validateTLSARecordData(data string) error {
+	fields := strings.Fields(data)
+	if len(fields) != 4 {
+		return fmt.Errorf("expected \"<usage> <selector> <matching-type> <hex data>\", got %q", data)
+	}
+
+	limits := []struct {
+		name string
+		max  uint64
+	}{
+		{"usage", 3},
+		{"selector", 1},
+		{"matching type", 2},
+	}
+	for i, limit := range limits {
+		v, err := strconv.ParseUint(fields[i], 10, 8)
+		if err != nil || v > limit.max {
+			return fmt.Errorf("%s must be a number between 0 and %d, got %q", limit.name, limit.max, fields[i])
+		}
+	}
+
+	payload, err := hex.DecodeString(fields[3])
+	if err != nil {
+		return fmt.Errorf("certificate association data must be hexadecimal: %w", err)
+	}
+
+	switch fields[2] {
+	case "1":
+		if len(payload) != 32 {
+			return fmt.Errorf("matching type 1 (SHA-256) expects 32 bytes of data, got %d", len(payload))
+		}
+	case "2":
+		if len(payload) != 64 {
+			return fmt.Errorf("matching type 2 (SHA-512) expects 64 bytes of data, got %d", len(payload))
+		}
+	}
+
+	return nil
+}
+
+// cnameConflictError returns an error if a record of recordType named
+// recordName conflicts with records of the zone: a name holding a CNAME may
+// not hold any other record. KAS accepts such combinations, but resolvers
+// then answer inconsistently. The record with ID ownID is ignored, as is a
+// record of the same type whose data equals recordData or, if recordData is
+// unknown, any record of the same type.
+func cnameConflictError(records []allinkl.ReturnInfo, zoneHost, recordType, recordName string, recordData types.String, ownID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, record := range records {
+		if fmt.Sprint(record.ID) == ownID || !hostnameEqual(record.RecordName, recordName) {
+			continue
+		}
+		if !strings.EqualFold(recordType, "CNAME") && !strings.EqualFold(record.RecordType, "CNAME") {
+			continue
+		}
+		if strings.EqualFold(recordType, record.RecordType) &&
+			(recordData.IsUnknown() || recordDataEqual(record.RecordType, record.RecordData, recordData.ValueString())) {
+			// The record itself, reported as a duplicate or adopted.
+			continue
+		}
+
+		name := recordName
+		if name == "" {
+			name = apexRecordName
+		}
+		diags.AddError(
+			"CNAME Conflict",
+			fmt.Sprintf("Zone %s already holds a %s record named %q (ID %s), so it cannot also hold a %s record of that name. "+
+				"A name with a CNAME record must not have any other records; KAS accepts the combination, but resolvers then answer "+
+				"inconsistently. Remove the conflicting record or choose another name.",
+				zoneHost, record.RecordType, name, fmt.Sprint(record.ID), strings.ToUpper(recordType)),
+		)
+		return diags
+	}
+	return diags
+}
+
+// validateZoneRecords returns an error for every record of a configured set
+// of records that breaks the rules resolvers rely on: a CNAME at the zone
+// apex, a CNAME sharing its name with other records, several CNAME records
+// of one name and exact duplicates. Records with unknown values are skipped.
+func validateZoneRecords(attribute path.Path, records []dnsZoneRecordModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for i, record := range records {
+		if record.Type.IsUnknown() || record.Name.IsUnknown() {
+			continue
+		}
+		isCNAME := strings.EqualFold(record.Type.ValueString(), "CNAME")
+		name := toKASRecordName(record.Name.ValueString())
+		if isCNAME && name == "" {
+			diags.AddAttributeError(
+				attribute,
+				"CNAME at Zone Apex",
+				fmt.Sprintf("Record %s: the zone apex always holds the SOA and NS records, so it cannot hold a CNAME record.",
+					describeZoneRecord(record)),
+			)
+			continue
+		}
+
+		for _, other := range records[:i] {
+			if other.Type.IsUnknown() || other.Name.IsUnknown() || !hostnameEqual(toKASRecordName(other.Name.ValueString()), name) {
+				continue
+			}
+			otherIsCNAME := strings.EqualFold(other.Type.ValueString(), "CNAME")
+			sameType := strings.EqualFold(other.Type.ValueString(), record.Type.ValueString())
+			duplicate := sameType && !record.Data.IsUnknown() && !other.Data.IsUnknown() &&
+				recordDataEqual(record.Type.ValueString(), record.Data.ValueString(), other.Data.ValueString()) &&
+				!record.Aux.IsUnknown() && !other.Aux.IsUnknown() && record.Aux.ValueInt64() == other.Aux.ValueInt64()
+
+			var summary, detail string
+			switch {
+			case duplicate:
+				summary = "Duplicate DNS Record"
+				detail = "it is configured more than once"
+			case isCNAME && otherIsCNAME:
+				summary = "Multiple CNAME Records"
+				detail = fmt.Sprintf("a name can hold only one CNAME record, but %s is configured as well", describeZoneRecord(other))
+			case isCNAME || otherIsCNAME:
+				summary = "CNAME Conflict"
+				detail = fmt.Sprintf("a name with a CNAME record must not have any other records, but %s is configured as well", describeZoneRecord(other))
+			default:
+				continue
+			}
+			diags.AddAttributeError(attribute, summary, fmt.Sprintf("Record %s: %s.", describeZoneRecord(record), detail))
+			break
+		}
+	}
+	return diags
+}
+
+// validateRecordData validates data against the format of recordType. Record
+// types without a known format are not validated.
+func validateRecordData(recordType, data string) error {
+	switch recordType {
+	case "A":
+		return validateARecordData(data)
+	case "AAAA":
+		return validateAAAARecordData(data)
+	case "CAA":
+		return validateCAARecordData(data)
+	case "CNAME", "NS":
+		return validateHostnameRecordData(recordType, data)
+	case "SRV":
+		return validateSRVRecordData(data)
+	case "TLSA":
+		return validateTLSARecordData(data)
+	default:
+		return nil
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ConfigValidator = recordAuxValidator{}
+)
+
+// recordAuxValidator requires a priority in record_aux for record types that
+// use it and rejects one for types where KAS ignores it.
+type recordAuxValidator struct{}
+
+func (v recordAuxValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v recordAuxValidator) MarkdownDescription(_ context.Context) string {
+	return "record_aux must be non-zero for MX and SRV records and zero for all other record types"
+}
+
+func (v recordAuxValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recordType types.String
+	var recordAux types.Int64
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("record_type"), &recordType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("record_aux"), &recordAux)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A null record_aux defaults to 0.
+	if recordType.IsNull() || recordType.IsUnknown() || recordAux.IsUnknown() {
+		return
+	}
+
+	switch {
+	case auxRecordTypes[recordType.ValueString()] && recordAux.ValueInt64() == 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("record_aux"),
+			"Missing Record Priority",
+			fmt.Sprintf("%s records require a non-zero record_aux holding the record priority.", recordType.ValueString()),
+		)
+	case !auxRecordTypes[recordType.ValueString()] && recordAux.ValueInt64() != 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("record_aux"),
+			"Unexpected Record Priority",
+			fmt.Sprintf("KAS ignores record_aux for %s records; set it to 0, got %d.", recordType.ValueString(), recordAux.ValueInt64()),
+		)
+	}
+}
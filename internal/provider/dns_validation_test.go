@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateRecordDataHostTypes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		recordType string
+		data       string
+		wantError  bool
+	}{
+		{recordType: "A", data: "192.0.2.1"},
+		{recordType: "A", data: "192.0.2.1 ", wantError: true},
+		{recordType: "A", data: "192.0.2.1 192.0.2.2", wantError: true},
+		{recordType: "AAAA", data: "2001:db8::1"},
+		{recordType: "AAAA", data: "\t2001:db8::1", wantError: true},
+		{recordType: "CNAME", data: "www.example.com."},
+		{recordType: "CNAME", data: "_dmarc.example.com"},
+		{recordType: "CNAME", data: "bücher.example"},
+		{recordType: "CNAME", data: "www.example.com .", wantError: true},
+		{recordType: "CNAME", data: "www..example.com", wantError: true},
+		{recordType: "CNAME", data: "https://www.example.com", wantError: true},
+		{recordType: "CNAME", data: "", wantError: true},
+		{recordType: "NS", data: "ns1.example.net"},
+		{recordType: "NS", data: "ns1.example.net,ns2.example.net", wantError: true},
+		{recordType: "NS", data: "a123456789012345678901234567890123456789012345678901234567890123.example", wantError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.recordType+" "+testCase.data, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateRecordData(testCase.recordType, testCase.data)
+			if (err != nil) != testCase.wantError {
+				t.Errorf("validateRecordData(%s, %q) = %v, want error %t", testCase.recordType, testCase.data, err, testCase.wantError)
+			}
+		})
+	}
+}
+
+func TestCNAMEConflictError(t *testing.T) {
+	t.Parallel()
+
+	records := []allinkl.ReturnInfo{
+		{ID: "1", RecordType: "CNAME", RecordName: "www", RecordData: "example.net."},
+		{ID: "2", RecordType: "A", RecordName: "", RecordData: "192.0.2.1"},
+		{ID: "3", RecordType: "TXT", RecordName: "mail", RecordData: "v=spf1 -all"},
+	}
+
+	testCases := map[string]struct {
+		recordType string
+		recordName string
+		recordData types.String
+		ownID      string
+		wantError  bool
+	}{
+		"a-at-cname":         {recordType: "A", recordName: "www", recordData: types.StringValue("192.0.2.2"), wantError: true},
+		"cname-at-txt":       {recordType: "CNAME", recordName: "mail", recordData: types.StringValue("example.net."), wantError: true},
+		"cname-at-apex":      {recordType: "CNAME", recordName: "", recordData: types.StringValue("example.net."), wantError: true},
+		"second-cname":       {recordType: "CNAME", recordName: "WWW", recordData: types.StringValue("example.org."), wantError: true},
+		"same-cname":         {recordType: "CNAME", recordName: "www", recordData: types.StringValue("EXAMPLE.NET")},
+		"unknown-cname-data": {recordType: "CNAME", recordName: "www", recordData: types.StringUnknown()},
+		"own-record":         {recordType: "CNAME", recordName: "www", recordData: types.StringValue("example.org."), ownID: "1"},
+		"other-name":         {recordType: "CNAME", recordName: "ftp", recordData: types.StringValue("example.net.")},
+		"a-at-txt":           {recordType: "A", recordName: "mail", recordData: types.StringValue("192.0.2.3")},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := cnameConflictError(records, "example.com", testCase.recordType, testCase.recordName, testCase.recordData, testCase.ownID)
+			if diags.HasError() != testCase.wantError {
+				t.Errorf("HasError() = %t, want %t: %v", diags.HasError(), testCase.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestValidateZoneRecords(t *testing.T) {
+	t.Parallel()
+
+	record := func(recordType, name string, data types.String, aux int64) dnsZoneRecordModel {
+		return dnsZoneRecordModel{
+			Type: types.StringValue(recordType),
+			Name: types.StringValue(name),
+			Data: data,
+			Aux:  types.Int64Value(aux),
+		}
+	}
+	cname := record("CNAME", "www", types.StringValue("example.net."), 0)
+	a := record("A", "www", types.StringValue("192.0.2.1"), 0)
+	mx := record("MX", "", types.StringValue("mail.example.com."), 10)
+
+	testCases := map[string]struct {
+		records    []dnsZoneRecordModel
+		wantErrors int
+	}{
+		"valid":              {records: []dnsZoneRecordModel{cname, mx, record("A", "ftp", types.StringValue("192.0.2.1"), 0)}},
+		"cname-with-a":       {records: []dnsZoneRecordModel{cname, a}, wantErrors: 1},
+		"cname-with-others":  {records: []dnsZoneRecordModel{a, record("TXT", "WWW", types.StringValue("v=spf1 -all"), 0), cname}, wantErrors: 1},
+		"two-cnames":         {records: []dnsZoneRecordModel{cname, record("CNAME", "www", types.StringValue("example.org."), 0)}, wantErrors: 1},
+		"three-cnames":       {records: []dnsZoneRecordModel{cname, record("CNAME", "www", types.StringValue("example.org."), 0), record("CNAME", "www", types.StringValue("example.com."), 0)}, wantErrors: 2},
+		"cname-at-apex":      {records: []dnsZoneRecordModel{record("CNAME", "", types.StringValue("example.net."), 0)}, wantErrors: 1},
+		"cname-at-at":        {records: []dnsZoneRecordModel{record("CNAME", "@", types.StringValue("example.net."), 0)}, wantErrors: 1},
+		"duplicate":          {records: []dnsZoneRecordModel{mx, record("MX", "@", types.StringValue("MAIL.example.com"), 10)}, wantErrors: 1},
+		"duplicate-cname":    {records: []dnsZoneRecordModel{cname, record("CNAME", "www", types.StringValue("EXAMPLE.NET"), 0)}, wantErrors: 1},
+		"other-aux":          {records: []dnsZoneRecordModel{mx, record("MX", "", types.StringValue("mail.example.com."), 20)}},
+		"unknown-data":       {records: []dnsZoneRecordModel{a, record("A", "www", types.StringUnknown(), 0)}},
+		"unknown-cname-name": {records: []dnsZoneRecordModel{a, {Type: types.StringValue("CNAME"), Name: types.StringUnknown(), Data: types.StringValue("example.net."), Aux: types.Int64Value(0)}}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateZoneRecords(path.Root("records"), testCase.records)
+			if diags.ErrorsCount() != testCase.wantErrors {
+				t.Errorf("ErrorsCount() = %d, want %d: %v", diags.ErrorsCount(), testCase.wantErrors, diags)
+			}
+		})
+	}
+}
+
+// validateResourceConfig runs ValidateResourceConfig of the resource typeName
+// with the given attributes set and all others null.
+func validateResourceConfig(t *testing.T, typeName string, attributes func(tftypes.Object) map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	objectType := schemaResp.ResourceSchemas[typeName].ValueType().(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attributes(objectType) {
+		values[name] = value
+	}
+
+	config, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err := server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   &config,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return resp.Diagnostics
+}
+
+func TestDNSRecordSetValidateConfigUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	diags := validateResourceConfig(t, "allinkl_dns_record_set", func(objectType tftypes.Object) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"zone_host":   tftypes.NewValue(tftypes.String, "example.com"),
+			"record_type": tftypes.NewValue(tftypes.String, "A"),
+			"values":      tftypes.NewValue(objectType.AttributeTypes["values"], tftypes.UnknownValue),
+		}
+	})
+	for _, d := range diags {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsZoneDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsZoneDataSource{}
+)
+
+// NewDNSZoneDataSource is a helper function to simplify the provider implementation.
+func NewDNSZoneDataSource() datasource.DataSource {
+	return &dnsZoneDataSource{}
+}
+
+// dnsZoneDataSource is the data source implementation.
+type dnsZoneDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsZoneDataSourceModel maps the data source schema data.
+type dnsZoneDataSourceModel struct {
+	ZoneHost              types.String           `tfsdk:"zone_host"`
+	CheckSOA              types.Bool             `tfsdk:"check_soa"`
+	Managed               types.Bool             `tfsdk:"managed"`
+	Nameservers           types.List             `tfsdk:"nameservers"`
+	RecordCount           types.Int64            `tfsdk:"record_count"`
+	ChangeableRecordCount types.Int64            `tfsdk:"changeable_record_count"`
+	RecordCounts          map[string]types.Int64 `tfsdk:"record_counts"`
+	SOASerial             types.Int64            `tfsdk:"soa_serial"`
+	TTL                   types.Int64            `tfsdk:"ttl"`
+}
+
+// Metadata returns the data source type name.
+func (d *dnsZoneDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsZoneDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns zone-level information: whether KAS manages DNS for the zone, its record counts and nameservers, " +
+			"and optionally the SOA served by the nameservers, e.g. for registrar delegation checks.",
+		Attributes: map[string]schema.Attribute{
+			"zone_host": schema.StringAttribute{
+				Required: true,
+			},
+			"check_soa": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Query the nameservers of the zone for its SOA to populate `soa_serial` and `ttl`, warning about nameservers that do not serve the zone.",
+			},
+			"managed": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether KAS manages DNS for the zone.",
+			},
+			"nameservers": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The nameservers KAS assigned to the zone, for delegating the zone at the registrar. Empty if the zone is not managed.",
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of records of the zone, including system records.",
+			},
+			"changeable_record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of records of the zone KAS allows to change.",
+			},
+			"record_counts": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The number of records of the zone by record type.",
+			},
+			"soa_serial": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The highest SOA serial served by the nameservers. Only set with `check_soa`.",
+			},
+			"ttl": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The TTL KAS serves the records of the zone with. Only set with `check_soa`.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	var state dnsZoneDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.GetDNSSettings(ctx, toASCIIHostname(state.ZoneHost.ValueString()), "")
+	if err != nil && !allinkl.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl DNS Zone",
+			"Could not read zone "+state.ZoneHost.ValueString()+": "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	state.Managed = types.BoolValue(err == nil)
+	state.Nameservers = zoneNameserversValue(records)
+	state.RecordCount = types.Int64Value(int64(len(records)))
+	state.ChangeableRecordCount = types.Int64Value(int64(len(changeableRecords(records))))
+	state.RecordCounts = map[string]types.Int64{}
+	for recordType, count := range recordCountsByType(records) {
+		state.RecordCounts[recordType] = types.Int64Value(count)
+	}
+
+	state.SOASerial, state.TTL = types.Int64Null(), types.Int64Null()
+	if state.CheckSOA.ValueBool() && err == nil {
+		nameservers := zoneNameservers(records)
+		if len(nameservers) == 0 {
+			nameservers = defaultNameservers
+		}
+		state.SOASerial, state.TTL, diags = checkZoneSOA(ctx, nameservers, state.ZoneHost.ValueString())
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// recordCountsByType counts records by their upper-case record type.
+func recordCountsByType(records []allinkl.ReturnInfo) map[string]int64 {
+	counts := map[string]int64{}
+	for _, record := range records {
+		counts[strings.ToUpper(record.RecordType)]++
+	}
+	return counts
+}
+
+func (d *dnsZoneDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsZoneExistsDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsZoneExistsDataSource{}
+)
+
+// NewDNSZoneExistsDataSource is a helper function to simplify the provider implementation.
+func NewDNSZoneExistsDataSource() datasource.DataSource {
+	return &dnsZoneExistsDataSource{}
+}
+
+// dnsZoneExistsDataSource is the data source implementation.
+type dnsZoneExistsDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsZoneExistsDataSourceModel maps the data source schema data.
+type dnsZoneExistsDataSourceModel struct {
+	ZoneHost    types.String `tfsdk:"zone_host"`
+	Exists      types.Bool   `tfsdk:"exists"`
+	Nameservers types.List   `tfsdk:"nameservers"`
+}
+
+// Metadata returns the data source type name.
+func (d *dnsZoneExistsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_exists"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsZoneExistsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks whether a zone is managed by the All-Inkl nameservers, for use in `check` blocks and preconditions.",
+		Attributes: map[string]schema.Attribute{
+			"zone_host": schema.StringAttribute{
+				Required: true,
+			},
+			"exists": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether KAS manages DNS for the zone.",
+			},
+			"nameservers": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The nameservers KAS assigned to the zone, for delegating the zone at the registrar. Empty if the zone does not exist.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsZoneExistsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	var state dnsZoneExistsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.GetDNSSettings(ctx, toASCIIHostname(state.ZoneHost.ValueString()), "")
+	switch {
+	case allinkl.IsNotFound(err):
+		state.Exists = types.BoolValue(false)
+		state.Nameservers = types.ListValueMust(types.StringType, nil)
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl DNS Zone",
+			"Could not read zone "+state.ZoneHost.ValueString()+": "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	default:
+		state.Exists = types.BoolValue(true)
+		state.Nameservers = zoneNameserversValue(records)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *dnsZoneExistsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
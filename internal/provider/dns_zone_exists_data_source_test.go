@@ -0,0 +1,41 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDNSZoneExistsDataSource(t *testing.T) {
+	server := newServer(t)
+	// Only the non-changeable apex NS records name the nameservers KAS
+	// assigned to the zone.
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Type: "NS", Data: "ns.example.net.", Changeable: true})
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "sub", Type: "NS", Data: "ns.example.org.", Changeable: true})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+data "allinkl_dns_zone_exists" "example" {
+  zone_host = "example.com"
+}
+
+data "allinkl_dns_zone_exists" "missing" {
+  zone_host = "example.org"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.allinkl_dns_zone_exists.example", "exists", "true"),
+					resource.TestCheckResourceAttr("data.allinkl_dns_zone_exists.example", "nameservers.#", "2"),
+					resource.TestCheckResourceAttr("data.allinkl_dns_zone_exists.example", "nameservers.0", "ns5.kasserver.com"),
+					resource.TestCheckResourceAttr("data.allinkl_dns_zone_exists.example", "nameservers.1", "ns6.kasserver.com"),
+					resource.TestCheckResourceAttr("data.allinkl_dns_zone_exists.missing", "exists", "false"),
+					resource.TestCheckResourceAttr("data.allinkl_dns_zone_exists.missing", "nameservers.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsZoneImportDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsZoneImportDataSource{}
+)
+
+// NewDNSZoneImportDataSource is a helper function to simplify the provider implementation.
+func NewDNSZoneImportDataSource() datasource.DataSource {
+	return &dnsZoneImportDataSource{}
+}
+
+// dnsZoneImportDataSource is the data source implementation.
+type dnsZoneImportDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsZoneImportDataSourceModel maps the data source schema data.
+type dnsZoneImportDataSourceModel struct {
+	ZoneHost   types.String            `tfsdk:"zone_host"`
+	RecordType types.String            `tfsdk:"record_type"`
+	ImportIDs  map[string]types.String `tfsdk:"import_ids"`
+}
+
+// Metadata returns the data source type name.
+func (d *dnsZoneImportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_import"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsZoneImportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the import IDs of the changeable records of a zone, to import every record of the zone at once " +
+			"with an `import` block using `for_each`, instead of one `terraform import` per record.",
+		Attributes: map[string]schema.Attribute{
+			"zone_host": schema.StringAttribute{
+				Required: true,
+			},
+			"record_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return records of this type.",
+			},
+			"import_ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The `zone_host/record_id` import IDs of the records, keyed by KAS record ID.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsZoneImportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	var state dnsZoneImportDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.GetDNSSettings(ctx, toASCIIHostname(state.ZoneHost.ValueString()), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl DNS Zone",
+			"Could not read zone "+state.ZoneHost.ValueString()+": "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	state.ImportIDs = map[string]types.String{}
+	for recordID, importID := range zoneImportIDs(state.ZoneHost.ValueString(), records, state.RecordType.ValueString()) {
+		state.ImportIDs[recordID] = types.StringValue(importID)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// zoneImportIDs returns the import IDs of the changeable records of zoneHost,
+// keyed by record ID. If recordType is not empty only records of that type
+// are returned.
+func zoneImportIDs(zoneHost string, records []allinkl.ReturnInfo, recordType string) map[string]string {
+	importIDs := map[string]string{}
+	for _, record := range records {
+		if record.Changeable != "Y" || (recordType != "" && !strings.EqualFold(record.RecordType, recordType)) {
+			continue
+		}
+		recordID := fmt.Sprint(record.ID)
+		importIDs[recordID] = formatDNSImportID(zoneHost, recordID)
+	}
+	return importIDs
+}
+
+func (d *dnsZoneImportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
@@ -0,0 +1,469 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &dnsZoneResource{}
+	_ resource.ResourceWithConfigure      = &dnsZoneResource{}
+	_ resource.ResourceWithImportState    = &dnsZoneResource{}
+	_ resource.ResourceWithModifyPlan     = &dnsZoneResource{}
+	_ resource.ResourceWithValidateConfig = &dnsZoneResource{}
+)
+
+// NewDNSZoneResource is a helper function to simplify the provider implementation.
+func NewDNSZoneResource() resource.Resource {
+	return &dnsZoneResource{}
+}
+
+// dnsZoneResource is the resource implementation.
+type dnsZoneResource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsZoneResourceModel maps the resource schema data.
+type dnsZoneResourceModel struct {
+	ID           types.String         `tfsdk:"id"`
+	ZoneHost     types.String         `tfsdk:"zone_host"`
+	Records      []dnsZoneRecordModel `tfsdk:"records"`
+	Zonefile     types.String         `tfsdk:"zonefile"`
+	Nameservers  types.List           `tfsdk:"nameservers"`
+	MaxDeletions types.Int64          `tfsdk:"max_deletions"`
+}
+
+// Metadata returns the resource type name.
+func (r *dnsZoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone"
+}
+
+// Schema defines the schema for the resource.
+func (r *dnsZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the complete set of changeable records of a zone. Records of the zone that are not configured " +
+			"are deleted, so the zone matches the configuration exactly. System records (`record_changeable = false`) are left alone. " +
+			"The records are configured either as `records` or as a BIND zone file in `zonefile`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_host": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfZoneChanged(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The changeable records of the zone. Computed from `zonefile` if that is set instead.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								stringOneOf(supportedRecordTypes...),
+							},
+						},
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The NAME of the record, empty or `@` for the zone apex.",
+						},
+						"data": schema.StringAttribute{
+							Required: true,
+						},
+						"aux": schema.Int64Attribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(0),
+							MarkdownDescription: "The AUX of the record, e.g. the MX preference. Defaults to `0`.",
+						},
+					},
+				},
+			},
+			"zonefile": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The records of the zone as BIND zone file text, e.g. from `file()`, instead of `records`. " +
+					"Names are relative to `zone_host` unless the file sets an `$ORIGIN`. SOA and apex NS records are skipped as KAS manages them; " +
+					"TTLs and classes are ignored.",
+			},
+			"nameservers": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The nameservers KAS assigned to the zone, for delegating the zone at the registrar.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_deletions": maxDeletionsSchemaAttribute(),
+		},
+	}
+}
+
+// ValidateConfig checks that exactly one of records and zonefile is set,
+// validates the data of every configured record and checks the records as a
+// whole, e.g. for CNAME conflicts.
+func (r *dnsZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var records types.Set
+	var zoneHost, zonefile types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("records"), &records)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone_host"), &zoneHost)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zonefile"), &zonefile)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case records.IsNull() && zonefile.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("records"),
+			"Missing Zone Records",
+			"Either records or zonefile must be set.",
+		)
+		return
+	case !records.IsNull() && !zonefile.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("zonefile"),
+			"Conflicting Zone Records",
+			"Only one of records and zonefile can be set.",
+		)
+		return
+	}
+
+	if !zonefile.IsNull() {
+		if !zonefile.IsUnknown() && !zoneHost.IsUnknown() {
+			parsed, err := parseZonefile(zonefile.ValueString(), zoneHost.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("zonefile"), "Invalid Zone File", err.Error())
+				return
+			}
+			records := make([]dnsZoneRecordModel, 0, len(parsed))
+			for _, record := range parsed {
+				records = append(records, record.zoneRecord())
+			}
+			resp.Diagnostics.Append(validateZoneRecords(path.Root("zonefile"), records)...)
+		}
+		return
+	}
+	if records.IsUnknown() {
+		return
+	}
+
+	var config dnsZoneResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, record := range config.Records {
+		if record.Type.IsUnknown() || record.Data.IsUnknown() || record.Type.IsNull() || record.Data.IsNull() {
+			continue
+		}
+		if err := validateRecordData(record.Type.ValueString(), record.Data.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records"),
+				"Invalid "+record.Type.ValueString()+" Record Data",
+				fmt.Sprintf("Record %q: %s", record.Name.ValueString(), err),
+			)
+		}
+	}
+	resp.Diagnostics.Append(validateZoneRecords(path.Root("records"), config.Records)...)
+}
+
+// ModifyPlan plans the records of a zonefile, warns if the plan creates or
+// deletes NS records and enforces max_deletions. On create, the records
+// already in the zone count as state, as creating deletes those not planned.
+func (r *dnsZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state, plan dnsZoneResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(planZonefileRecords(ctx, resp)...)
+
+		// records may be unknown until apply when derived from other resources.
+		var planned types.Set
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("records"), &planned)...)
+		if resp.Diagnostics.HasError() || planned.IsUnknown() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneHost := plan.ZoneHost.ValueString()
+	if req.Plan.Raw.IsNull() {
+		zoneHost = state.ZoneHost.ValueString()
+	}
+
+	if req.State.Raw.IsNull() && r.providerData != nil && !plan.ZoneHost.IsUnknown() {
+		existing, diags := existingZoneRecords(ctx, r.providerData, zoneHost, plan.Records)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(existingRecordsWarning(zoneHost, existing, plan.Records)...)
+		state.Records = existing
+	}
+
+	resp.Diagnostics.Append(infrastructureRecordWarning(zoneHost, state.Records, plan.Records)...)
+	resp.Diagnostics.Append(r.providerData.protectInfrastructureRecords(zoneHost, state.Records, plan.Records)...)
+	if !req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(checkMaxDeletions(zoneHost, plan.MaxDeletions, plannedDeletions(state.Records, plan.Records))...)
+	}
+}
+
+// planZonefileRecords sets the planned records to the records of the planned
+// zonefile, if one is set and known.
+func planZonefileRecords(ctx context.Context, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var zoneHost, zonefile types.String
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("zone_host"), &zoneHost)...)
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("zonefile"), &zonefile)...)
+	if diags.HasError() || zonefile.IsNull() || zonefile.IsUnknown() || zoneHost.IsUnknown() {
+		return diags
+	}
+
+	parsed, err := parseZonefile(zonefile.ValueString(), zoneHost.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("zonefile"), "Invalid Zone File", err.Error())
+		return diags
+	}
+
+	records := make([]dnsZoneRecordModel, 0, len(parsed))
+	for _, record := range parsed {
+		records = append(records, record.zoneRecord())
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), records)...)
+	return diags
+}
+
+func (r *dnsZoneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+// Create reconciles the zone with the planned records.
+func (r *dnsZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan dnsZoneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, diags := readDNSZoneRecords(ctx, r.providerData, plan.ZoneHost.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Nameservers = zoneNameserversValue(records)
+	plan.ID = types.StringValue(normalizeHostname(plan.ZoneHost.ValueString()))
+
+	diags = reconcileDNSRecords(ctx, r.providerData, plan.ZoneHost.ValueString(), managedRecords(records), plan.Records, plan.MaxDeletions)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		// Keep the partially reconciled zone in state, so the next apply
+		// continues from the records it holds now.
+		r.setPartialState(ctx, plan, &resp.State, &resp.Diagnostics)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// setPartialState sets state to plan with the records the zone holds after a
+// failed reconcile. Nothing is set if the zone cannot be read.
+func (r *dnsZoneResource) setPartialState(ctx context.Context, plan dnsZoneResourceModel, state *tfsdk.State, diagnostics *diag.Diagnostics) {
+	records, diags := readChangeableDNSRecords(ctx, r.providerData, plan.ZoneHost.ValueString())
+	if diags.HasError() {
+		return
+	}
+
+	partial := []dnsZoneRecordModel{}
+	for _, record := range records {
+		partial = append(partial, dnsZoneRecordValue(plan.Records, record))
+	}
+	plan.Records = partial
+
+	diagnostics.Append(state.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the changeable records of the zone.
+func (r *dnsZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	var state dnsZoneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := r.client.GetDNSSettings(ctx, toASCIIHostname(state.ZoneHost.ValueString()), "")
+	if allinkl.IsNotFound(err) {
+		tflog.Warn(ctx, "AllInkl dns zone not found, removing from state", map[string]any{
+			"zone_host": state.ZoneHost.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading AllInkl DNS Zone",
+			"Could not read zone "+state.ZoneHost.ValueString()+": "+r.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	refreshed := []dnsZoneRecordModel{}
+	for _, record := range managedRecords(records) {
+		refreshed = append(refreshed, dnsZoneRecordValue(state.Records, record))
+	}
+	state.Records = refreshed
+	state.Nameservers = zoneNameserversValue(records)
+	if len(records) > 0 {
+		state.ZoneHost = zoneHostValue(state.ZoneHost, records[0].ZoneHost)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update reconciles the zone with the planned records.
+func (r *dnsZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan dnsZoneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, diags := readDNSZoneRecords(ctx, r.providerData, plan.ZoneHost.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(reconcileDNSRecords(ctx, r.providerData, plan.ZoneHost.ValueString(), managedRecords(records), plan.Records, plan.MaxDeletions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Nameservers = zoneNameserversValue(records)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the records of the zone managed by the resource.
+func (r *dnsZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state dnsZoneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := readChangeableDNSRecords(ctx, r.providerData, state.ZoneHost.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, record := range current {
+		if containsZoneRecord(state.Records, record) {
+			resp.Diagnostics.Append(deleteDNSRecord(ctx, r.providerData, record)...)
+		}
+	}
+}
+
+// ImportState imports a zone by its zone_host.
+func (r *dnsZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	zoneHost := strings.TrimSpace(req.ID)
+	if zoneHost == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected the zone_host as import ID.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(verifyImportedDNSZone(ctx, r.providerData, req.ID, zoneHost)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_host"), zoneHost)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), normalizeHostname(zoneHost))...)
+}
@@ -0,0 +1,97 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const dnsZoneConfig = `
+resource "allinkl_dns_zone" "example" {
+  zone_host = "example.com"
+  records = [
+    { type = "A", name = "www", data = "192.0.2.1" },
+    { type = "CNAME", name = "mail", data = "www.example.com." },
+  ]
+}
+`
+
+func TestDNSZoneResourceReconcilesExistingRecords(t *testing.T) {
+	server := newServer(t)
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "www", Type: "A", Data: "192.0.2.1", Changeable: true})
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "old", Type: "A", Data: "192.0.2.9", Changeable: true})
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "mail", Type: "A", Data: "192.0.2.2", Changeable: true})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + dnsZoneConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("allinkl_dns_zone.example", "id", "example.com"),
+					resource.TestCheckResourceAttr("allinkl_dns_zone.example", "nameservers.#", "2"),
+					resource.TestCheckResourceAttr("allinkl_dns_zone.example", "nameservers.0", "ns5.kasserver.com"),
+					resource.TestCheckResourceAttr("allinkl_dns_zone.example", "nameservers.1", "ns6.kasserver.com"),
+					checkRecords(server, "example.com", "A www 192.0.2.1", "CNAME mail www.example.com."),
+				),
+			},
+		},
+		CheckDestroy: checkRecords(server, "example.com"),
+	})
+}
+
+func TestDNSZoneResourceCreateMaxDeletions(t *testing.T) {
+	server := newServer(t)
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "old", Type: "A", Data: "192.0.2.9", Changeable: true})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_dns_zone" "example" {
+  zone_host     = "example.com"
+  records       = [{ type = "A", name = "www", data = "192.0.2.1" }]
+  max_deletions = 0
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`This change deletes 1 records of zone example.com`),
+			},
+		},
+	})
+
+	if err := compareRecords(server, "example.com", "A old 192.0.2.9"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDNSZoneResourceCreateKeepsRecordsOnFailedAdd(t *testing.T) {
+	server := newServer(t)
+	server.AddRecord(allinkltest.Record{Zone: "example.com", Name: "old", Type: "A", Data: "192.0.2.9", Changeable: true})
+	server.SetFault("add_dns_settings", "record_data_syntax_incorrect")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      server.ProviderConfig() + dnsZoneConfig,
+				ExpectError: regexp.MustCompile(`Error Creating AllInkl DNS`),
+			},
+			{
+				// The failed create left the zone untouched but in state.
+				PreConfig: func() {
+					if err := compareRecords(server, "example.com", "A old 192.0.2.9"); err != nil {
+						t.Error(err)
+					}
+					server.SetFault("add_dns_settings", "")
+				},
+				Config: server.ProviderConfig() + dnsZoneConfig,
+				Check:  checkRecords(server, "example.com", "A www 192.0.2.1", "CNAME mail www.example.com."),
+			},
+		},
+		CheckDestroy: checkRecords(server, "example.com"),
+	})
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &dnsZoneRestoreResource{}
+	_ resource.ResourceWithConfigure  = &dnsZoneRestoreResource{}
+	_ resource.ResourceWithModifyPlan = &dnsZoneRestoreResource{}
+)
+
+// NewDNSZoneRestoreResource is a helper function to simplify the provider implementation.
+func NewDNSZoneRestoreResource() resource.Resource {
+	return &dnsZoneRestoreResource{}
+}
+
+// dnsZoneRestoreResource is the resource implementation.
+type dnsZoneRestoreResource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsZoneRestoreResourceModel maps the resource schema data.
+type dnsZoneRestoreResourceModel struct {
+	ID           types.String         `tfsdk:"id"`
+	ZoneHost     types.String         `tfsdk:"zone_host"`
+	Records      []dnsZoneRecordModel `tfsdk:"records"`
+	MaxDeletions types.Int64          `tfsdk:"max_deletions"`
+}
+
+// Metadata returns the resource type name.
+func (r *dnsZoneRestoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_restore"
+}
+
+// Schema defines the schema for the resource.
+func (r *dnsZoneRestoreResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Restores the changeable records of a zone, usually from the `records` of an `allinkl_dns_zone_snapshot`. " +
+			"Records are restored when the resource is created or `records` change; later changes to the zone are not " +
+			"reverted and destroying the resource leaves the zone as it is.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_host": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfZoneChanged(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The changeable records the zone is restored to. Records of the zone that are not listed are deleted.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required: true,
+						},
+						"name": schema.StringAttribute{
+							Required: true,
+						},
+						"data": schema.StringAttribute{
+							Required: true,
+						},
+						"aux": schema.Int64Attribute{
+							Optional: true,
+							Computed: true,
+							Default:  int64default.StaticInt64(0),
+						},
+					},
+				},
+			},
+			"max_deletions": maxDeletionsSchemaAttribute(),
+		},
+	}
+}
+
+// ModifyPlan warns if restoring creates or deletes NS records and enforces
+// max_deletions against the previously restored records; the live zone is
+// checked again on apply. Destroying the resource leaves the zone alone, so
+// it is not checked.
+func (r *dnsZoneRestoreResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// records may be unknown until apply, e.g. when taken from a snapshot.
+	var planned types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("records"), &planned)...)
+	if resp.Diagnostics.HasError() || planned.IsUnknown() {
+		return
+	}
+
+	var state, plan dnsZoneRestoreResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(infrastructureRecordWarning(plan.ZoneHost.ValueString(), state.Records, plan.Records)...)
+	resp.Diagnostics.Append(checkMaxDeletions(plan.ZoneHost.ValueString(), plan.MaxDeletions, plannedDeletions(state.Records, plan.Records))...)
+}
+
+func (r *dnsZoneRestoreResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+// Create restores the zone to the planned records.
+func (r *dnsZoneRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan dnsZoneRestoreResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.restore(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(normalizeHostname(plan.ZoneHost.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the restored records; changes made to the zone afterwards are
+// not reported as drift.
+func (r *dnsZoneRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update restores the zone to the planned records.
+func (r *dnsZoneRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan dnsZoneRestoreResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.restore(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from state without changing the zone.
+func (r *dnsZoneRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// restore reconciles the changeable records of the zone with the planned
+// records.
+func (r *dnsZoneRestoreResource) restore(ctx context.Context, plan dnsZoneRestoreResourceModel) diag.Diagnostics {
+	current, diags := readChangeableDNSRecords(ctx, r.providerData, plan.ZoneHost.ValueString())
+	if diags.HasError() {
+		return diags
+	}
+
+	// The zone is compared with the live records rather than the state, so
+	// protected deletions can only be detected now.
+	var currentRecords []dnsZoneRecordModel
+	for _, record := range current {
+		currentRecords = append(currentRecords, dnsZoneRecordValue(plan.Records, record))
+	}
+	diags.Append(r.providerData.protectInfrastructureRecords(plan.ZoneHost.ValueString(), currentRecords, plan.Records)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(reconcileDNSRecords(ctx, r.providerData, plan.ZoneHost.ValueString(), current, plan.Records, plan.MaxDeletions)...)
+	return diags
+}
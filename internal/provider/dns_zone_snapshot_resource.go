@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &dnsZoneSnapshotResource{}
+	_ resource.ResourceWithConfigure = &dnsZoneSnapshotResource{}
+)
+
+// NewDNSZoneSnapshotResource is a helper function to simplify the provider implementation.
+func NewDNSZoneSnapshotResource() resource.Resource {
+	return &dnsZoneSnapshotResource{}
+}
+
+// dnsZoneSnapshotResource is the resource implementation.
+type dnsZoneSnapshotResource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsZoneSnapshotResourceModel maps the resource schema data.
+type dnsZoneSnapshotResourceModel struct {
+	ID        types.String         `tfsdk:"id"`
+	ZoneHost  types.String         `tfsdk:"zone_host"`
+	Triggers  types.Map            `tfsdk:"triggers"`
+	Records   []dnsZoneRecordModel `tfsdk:"records"`
+	CreatedAt types.String         `tfsdk:"created_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *dnsZoneSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_snapshot"
+}
+
+// Schema defines the schema for the resource.
+func (r *dnsZoneSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Captures the changeable records of a zone when created. The snapshot is kept in state and never refreshed, " +
+			"so it can later be restored with `allinkl_dns_zone_restore`. Change `triggers` to take a new snapshot. " +
+			"Destroying the snapshot does not change the zone.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_host": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfZoneChanged(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that take a new snapshot when changed.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The changeable records of the zone at the time of the snapshot.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"data": schema.StringAttribute{
+							Computed: true,
+						},
+						"aux": schema.Int64Attribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the snapshot was taken, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *dnsZoneSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+// Create reads the changeable records of the zone into the snapshot.
+func (r *dnsZoneSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	var plan dnsZoneSnapshotResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := readChangeableDNSRecords(ctx, r.providerData, plan.ZoneHost.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Records = []dnsZoneRecordModel{}
+	for _, record := range current {
+		plan.Records = append(plan.Records, dnsZoneRecordValue(nil, record))
+	}
+
+	now := time.Now()
+	plan.ID = types.StringValue(normalizeHostname(plan.ZoneHost.ValueString()) + "@" + now.UTC().Format(time.RFC3339))
+	plan.CreatedAt = types.StringValue(now.UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the snapshot as taken; it is never refreshed from the zone.
+func (r *dnsZoneSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only stores changes that do not take a new snapshot.
+func (r *dnsZoneSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dnsZoneSnapshotResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the snapshot from state without changing the zone.
+func (r *dnsZoneSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,396 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// zonefileRecord is a record parsed from a BIND zone file, in the format of
+// the records of allinkl_dns_zone.
+type zonefileRecord struct {
+	Type string
+	Name string
+	Data string
+	Aux  int64
+}
+
+// zoneRecord returns the record as a record of allinkl_dns_zone.
+func (r zonefileRecord) zoneRecord() dnsZoneRecordModel {
+	return dnsZoneRecordModel{
+		Type: types.StringValue(r.Type),
+		Name: types.StringValue(r.Name),
+		Data: types.StringValue(r.Data),
+		Aux:  types.Int64Value(r.Aux),
+	}
+}
+
+// zonefileLine is a logical line of a zone file, with parentheses joined.
+type zonefileLine struct {
+	number int
+	// blankOwner is set if the line starts with whitespace, so the record
+	// belongs to the owner of the previous record.
+	blankOwner bool
+	tokens     []string
+}
+
+// parseZonefile parses the records of a BIND zone file. Record names are
+// returned relative to origin, empty for the zone apex. $ORIGIN directives
+// in the file override origin. SOA and apex NS records are skipped, as KAS
+// manages them itself.
+func parseZonefile(text, origin string) ([]zonefileRecord, error) {
+	lines, err := splitZonefile(text)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := strings.ToLower(strings.TrimSuffix(origin, "."))
+	current := zone
+	owner := ""
+	ownerSet := false
+
+	records := []zonefileRecord{}
+	for _, line := range lines {
+		tokens := line.tokens
+
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: expected $ORIGIN <domain>", line.number)
+			}
+			current = strings.ToLower(strings.TrimSuffix(absoluteZonefileName(tokens[1], current), "."))
+			if zone == "" {
+				zone = current
+			}
+			continue
+		case "$TTL":
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s directives are not supported", line.number, strings.ToUpper(tokens[0]))
+		}
+
+		if !line.blankOwner {
+			owner = absoluteZonefileName(tokens[0], current)
+			ownerSet = true
+			tokens = tokens[1:]
+		} else if !ownerSet {
+			return nil, fmt.Errorf("line %d: record without owner name", line.number)
+		}
+
+		// Skip the optional TTL and class, which may appear in either order.
+		for len(tokens) > 0 && (isZonefileTTL(tokens[0]) || isZonefileClass(tokens[0])) {
+			tokens = tokens[1:]
+		}
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("line %d: expected a record type and data", line.number)
+		}
+
+		recordType := strings.ToUpper(tokens[0])
+		rdata := tokens[1:]
+
+		name, err := relativeZonefileName(owner, zone)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+
+		if recordType == "SOA" || (recordType == "NS" && name == "") {
+			continue
+		}
+
+		record, err := zonefileRecordData(recordType, rdata, current)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		record.Name = name
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// zonefileRecordData converts the RDATA of a record into KAS record data.
+// Hostnames in the data are made absolute relative to origin.
+func zonefileRecordData(recordType string, rdata []string, origin string) (zonefileRecord, error) {
+	record := zonefileRecord{Type: recordType}
+
+	switch recordType {
+	case "A", "AAAA", "CNAME", "NS":
+		if len(rdata) != 1 {
+			return record, fmt.Errorf("%s records expect a single value, got %q", recordType, strings.Join(rdata, " "))
+		}
+		record.Data = rdata[0]
+		if hostnameRecordTypes[recordType] {
+			record.Data = absoluteZonefileName(rdata[0], origin)
+		}
+	case "MX":
+		if len(rdata) != 2 {
+			return record, fmt.Errorf("MX records expect \"<preference> <exchange>\", got %q", strings.Join(rdata, " "))
+		}
+		preference, err := strconv.ParseInt(rdata[0], 10, 64)
+		if err != nil {
+			return record, fmt.Errorf("invalid MX preference %q", rdata[0])
+		}
+		record.Aux = preference
+		record.Data = absoluteZonefileName(rdata[1], origin)
+	case "SRV":
+		if len(rdata) != 4 {
+			return record, fmt.Errorf("SRV records expect \"<priority> <weight> <port> <target>\", got %q", strings.Join(rdata, " "))
+		}
+		priority, err := strconv.ParseInt(rdata[0], 10, 64)
+		if err != nil {
+			return record, fmt.Errorf("invalid SRV priority %q", rdata[0])
+		}
+		record.Aux = priority
+		record.Data = rdata[1] + " " + rdata[2] + " " + absoluteZonefileName(rdata[3], origin)
+	case "CAA", "TLSA", "TXT":
+		record.Data = strings.Join(rdata, " ")
+	default:
+		return record, fmt.Errorf("record type %s is not supported by KAS", recordType)
+	}
+
+	if err := validateRecordData(recordType, record.Data); err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+// splitZonefile splits a zone file into logical lines of tokens, dropping
+// comments and joining lines enclosed in parentheses. Quoted strings are
+// kept as a single token including their quotes.
+func splitZonefile(text string) ([]zonefileLine, error) {
+	var lines []zonefileLine
+	var line zonefileLine
+	var token strings.Builder
+
+	number, depth := 1, 0
+	inToken, inQuotes, escaped, inComment, lineStart := false, false, false, false, true
+
+	endToken := func() {
+		if inToken {
+			line.tokens = append(line.tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+	endLine := func() {
+		endToken()
+		if len(line.tokens) > 0 {
+			lines = append(lines, line)
+		}
+		line = zonefileLine{}
+		lineStart = true
+	}
+
+	for _, c := range text {
+		if lineStart {
+			line.number = number
+			line.blankOwner = c == ' ' || c == '\t'
+			lineStart = false
+		}
+
+		switch {
+		case inComment:
+			if c != '\n' {
+				continue
+			}
+			inComment = false
+		case escaped:
+			token.WriteRune(c)
+			escaped = false
+			continue
+		case c == '\\':
+			token.WriteRune(c)
+			inToken = true
+			escaped = true
+			continue
+		case inQuotes:
+			if c == '\n' {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", number)
+			}
+			token.WriteRune(c)
+			inQuotes = c != '"'
+			continue
+		}
+
+		switch {
+		case c == '\n':
+			number++
+			if depth == 0 {
+				endLine()
+			} else {
+				endToken()
+			}
+		case c == ';':
+			endToken()
+			inComment = true
+		case c == '"':
+			token.WriteRune(c)
+			inToken = true
+			inQuotes = true
+		case c == '(':
+			endToken()
+			depth++
+		case c == ')':
+			endToken()
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", number)
+			}
+			depth--
+		case unicode.IsSpace(c):
+			endToken()
+		default:
+			token.WriteRune(c)
+			inToken = true
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", number)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", number)
+	}
+	endLine()
+
+	return lines, nil
+}
+
+// absoluteZonefileName returns name as an absolute name with trailing dot.
+// "@" denotes origin. Relative names are kept relative if origin is unknown,
+// with "@" becoming the empty apex name.
+func absoluteZonefileName(name, origin string) string {
+	switch {
+	case name == "@" && origin == "":
+		return ""
+	case name == "@":
+		return origin + "."
+	case strings.HasSuffix(name, "."):
+		return name
+	case origin == "":
+		return name
+	default:
+		return name + "." + origin + "."
+	}
+}
+
+// relativeZonefileName returns the absolute name relative to zone, empty for
+// the zone apex.
+func relativeZonefileName(name, zone string) (string, error) {
+	if !strings.HasSuffix(name, ".") {
+		// Without an origin names stay relative.
+		return name, nil
+	}
+	if zone == "" {
+		return "", fmt.Errorf("absolute name %q requires an origin", name)
+	}
+
+	trimmed := strings.TrimSuffix(name, ".")
+	lower := strings.ToLower(trimmed)
+	switch {
+	case lower == zone:
+		return "", nil
+	case strings.HasSuffix(lower, "."+zone):
+		return trimmed[:len(trimmed)-len(zone)-1], nil
+	default:
+		return "", fmt.Errorf("name %q is outside of zone %s", name, zone)
+	}
+}
+
+// isZonefileTTL reports whether token is a TTL such as 3600 or 1h30m.
+func isZonefileTTL(token string) bool {
+	if token == "" || token[0] < '0' || token[0] > '9' {
+		return false
+	}
+	for _, c := range strings.ToLower(token) {
+		if (c < '0' || c > '9') && !strings.ContainsRune("smhdw", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isZonefileClass reports whether token is a DNS class.
+func isZonefileClass(token string) bool {
+	switch strings.ToUpper(token) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
+
+// txtChunkLength is the maximum length of a character-string in TXT data.
+const txtChunkLength = 255
+
+// formatZonefile renders records of zone in BIND zone file format, with
+// names relative to an $ORIGIN of zone. A positive ttl is written as $TTL.
+// Hostnames in record data are written as absolute names.
+func formatZonefile(zone string, ttl int64, records []zonefileRecord) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", normalizeHostname(zone))
+	if ttl > 0 {
+		fmt.Fprintf(&b, "$TTL %d\n", ttl)
+	}
+	for _, record := range records {
+		name := record.Name
+		if name == "" {
+			name = "@"
+		}
+		fmt.Fprintf(&b, "%s\tIN\t%s\t%s\n", name, record.Type, formatZonefileRecordData(record))
+	}
+
+	return b.String()
+}
+
+// formatZonefileRecordData converts KAS record data into RDATA, the inverse
+// of zonefileRecordData.
+func formatZonefileRecordData(record zonefileRecord) string {
+	switch record.Type {
+	case "CNAME", "NS":
+		return absoluteZonefileHostname(record.Data)
+	case "MX":
+		return fmt.Sprintf("%d %s", record.Aux, absoluteZonefileHostname(record.Data))
+	case "SRV":
+		fields := strings.Fields(record.Data)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = absoluteZonefileHostname(fields[len(fields)-1])
+		}
+		return fmt.Sprintf("%d %s", record.Aux, strings.Join(fields, " "))
+	case "TXT":
+		return quoteTXT(record.Data)
+	default:
+		return record.Data
+	}
+}
+
+// absoluteZonefileHostname returns a hostname of KAS record data, which KAS
+// treats as fully qualified, with a trailing dot.
+func absoluteZonefileHostname(host string) string {
+	if strings.HasSuffix(host, ".") {
+		return host
+	}
+	return host + "."
+}
+
+// quoteTXT returns TXT data as quoted character-strings of at most 255
+// bytes. Data that already consists of quoted character-strings is kept.
+func quoteTXT(data string) string {
+	if unquoteTXT(data) != data {
+		return strings.TrimSpace(data)
+	}
+
+	var chunks []string
+	for len(data) > txtChunkLength {
+		chunks = append(chunks, data[:txtChunkLength])
+		data = data[txtChunkLength:]
+	}
+	chunks = append(chunks, data)
+
+	for i, chunk := range chunks {
+		chunks[i] = quoteCharacterString(chunk)
+	}
+	return strings.Join(chunks, " ")
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsZonefileDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsZonefileDataSource{}
+)
+
+// NewDNSZonefileDataSource is a helper function to simplify the provider implementation.
+func NewDNSZonefileDataSource() datasource.DataSource {
+	return &dnsZonefileDataSource{}
+}
+
+// dnsZonefileDataSource is the data source implementation.
+type dnsZonefileDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsZonefileDataSourceModel maps the data source schema data.
+type dnsZonefileDataSourceModel struct {
+	ZoneHost       types.String `tfsdk:"zone_host"`
+	TTL            types.Int64  `tfsdk:"ttl"`
+	ChangeableOnly types.Bool   `tfsdk:"changeable_only"`
+	Content        types.String `tfsdk:"content"`
+}
+
+// Metadata returns the data source type name.
+func (d *dnsZonefileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zonefile"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsZonefileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders the records of a zone as a BIND zone file, for backups and migrating to other DNS hosts. " +
+			"The output can be read back with `provider::allinkl::parse_zonefile`. KAS does not expose the SOA, so none is written.",
+		Attributes: map[string]schema.Attribute{
+			"zone_host": schema.StringAttribute{
+				Required: true,
+			},
+			"ttl": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "TTL to write as `$TTL` directive. By default no TTL is written.",
+			},
+			"changeable_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only render the records KAS allows to change, leaving out system records such as the apex NS records.",
+			},
+			"content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The zone file text.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsZonefileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	var state dnsZonefileDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, diags := readDNSZoneRecords(ctx, d.providerData, state.ZoneHost.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.ChangeableOnly.ValueBool() {
+		records = changeableRecords(records)
+	}
+
+	state.Content = types.StringValue(formatZonefile(state.ZoneHost.ValueString(), state.TTL.ValueInt64(), zonefileRecords(records)))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// zonefileRecords converts remote records, leaving out account lease
+// records, and orders them by name, type, aux and data for a stable output.
+func zonefileRecords(records []allinkl.ReturnInfo) []zonefileRecord {
+	result := make([]zonefileRecord, 0, len(records))
+	for _, record := range records {
+		if isLeaseRecord(record) {
+			continue
+		}
+		result = append(result, zonefileRecord{
+			Type: strings.ToUpper(record.RecordType),
+			Name: record.RecordName,
+			Data: record.RecordData,
+			Aux:  int64(record.RecordAux),
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		switch {
+		case a.Name != b.Name:
+			return a.Name < b.Name
+		case a.Type != b.Type:
+			return a.Type < b.Type
+		case a.Aux != b.Aux:
+			return a.Aux < b.Aux
+		default:
+			return a.Data < b.Data
+		}
+	})
+	return result
+}
+
+func (d *dnsZonefileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+)
+
+func TestParseZonefile(t *testing.T) {
+	t.Parallel()
+
+	text := `$ORIGIN example.com.
+$TTL 3600
+@	IN	SOA	ns5.kasserver.com. hostmaster.example.com. (
+			2024010101 ; serial
+			7200 3600 1209600 3600 )
+	IN	NS	ns5.kasserver.com.
+	IN	NS	ns6.kasserver.com.
+@	300 IN	A	192.0.2.1
+	IN	MX	10 mail
+	IN	TXT	"v=spf1 mx -all" ; comment
+www	CNAME	@
+WWW2.Example.COM.	IN	AAAA	2001:db8::1
+_sip._tcp	IN	SRV	10 60 5060 sip.example.net.
+sub	IN	NS	ns1.other.example.
+@	CAA	0 issue "letsencrypt.org"
+long	TXT	( "part one"
+		"part; two" )
+`
+
+	records, err := parseZonefile(text, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []zonefileRecord{
+		{Type: "A", Name: "", Data: "192.0.2.1"},
+		{Type: "MX", Name: "", Data: "mail.example.com.", Aux: 10},
+		{Type: "TXT", Name: "", Data: `"v=spf1 mx -all"`},
+		{Type: "CNAME", Name: "www", Data: "example.com."},
+		{Type: "AAAA", Name: "WWW2", Data: "2001:db8::1"},
+		{Type: "SRV", Name: "_sip._tcp", Data: "60 5060 sip.example.net.", Aux: 10},
+		{Type: "NS", Name: "sub", Data: "ns1.other.example."},
+		{Type: "CAA", Name: "", Data: `0 issue "letsencrypt.org"`},
+		{Type: "TXT", Name: "long", Data: `"part one" "part; two"`},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("unexpected records:\n got: %+v\nwant: %+v", records, want)
+	}
+}
+
+func TestParseZonefileOrigin(t *testing.T) {
+	t.Parallel()
+
+	records, err := parseZonefile("www.example.com. IN A 192.0.2.1\nmail IN A 192.0.2.2\n", "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []zonefileRecord{
+		{Type: "A", Name: "www", Data: "192.0.2.1"},
+		{Type: "A", Name: "mail", Data: "192.0.2.2"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("unexpected records:\n got: %+v\nwant: %+v", records, want)
+	}
+}
+
+func TestParseZonefileErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"outside-zone":       "$ORIGIN example.com.\nwww.example.org. IN A 192.0.2.1\n",
+		"absolute-no-origin": "www.example.com. IN A 192.0.2.1\n",
+		"unsupported-type":   "$ORIGIN example.com.\n@ IN PTR host.example.com.\n",
+		"include":            "$INCLUDE other.zone\n",
+		"invalid-a":          "$ORIGIN example.com.\n@ IN A not-an-ip\n",
+		"unterminated-quote": "$ORIGIN example.com.\n@ IN TXT \"open\n",
+		"unbalanced":         "$ORIGIN example.com.\n@ IN TXT ( \"open\"\n",
+		"no-owner":           " IN A 192.0.2.1\n",
+	}
+
+	for name, text := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if records, err := parseZonefile(text, ""); err == nil {
+				t.Fatalf("expected error, got %+v", records)
+			}
+		})
+	}
+}
+
+func TestFormatZonefile(t *testing.T) {
+	t.Parallel()
+
+	records := zonefileRecords([]allinkl.ReturnInfo{
+		{RecordType: "NS", RecordName: "", RecordData: "ns5.kasserver.com."},
+		{RecordType: "A", RecordName: "", RecordData: "192.0.2.1"},
+		{RecordType: "MX", RecordName: "", RecordData: "mail.example.com", RecordAux: 10},
+		{RecordType: "TXT", RecordName: "", RecordData: `v=spf1 include:"quoted" -all`},
+		{RecordType: "TXT", RecordName: "long", RecordData: strings.Repeat("a", 300)},
+		{RecordType: "TXT", RecordName: "chunks", RecordData: `"part one" "part two"`},
+		{RecordType: "CNAME", RecordName: "www", RecordData: "example.com"},
+		{RecordType: "SRV", RecordName: "_sip._tcp", RecordData: "60 5060 sip.example.net", RecordAux: 10},
+		{RecordType: "CAA", RecordName: "", RecordData: `0 issue "letsencrypt.org"`},
+		{RecordType: "TXT", RecordName: "_terraform-lease", RecordData: "v=tflease1; holder=x"},
+	})
+
+	text := formatZonefile("Example.com.", 3600, records)
+	if !strings.HasPrefix(text, "$ORIGIN example.com.\n$TTL 3600\n") {
+		t.Errorf("unexpected header:\n%s", text)
+	}
+	if !strings.Contains(text, "@\tIN\tMX\t10 mail.example.com.\n") {
+		t.Errorf("MX record not rendered as expected:\n%s", text)
+	}
+	if !strings.Contains(text, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`) {
+		t.Errorf("long TXT record not split into character-strings:\n%s", text)
+	}
+
+	parsed, err := parseZonefile(text, "")
+	if err != nil {
+		t.Fatalf("parseZonefile: unexpected error: %s\n%s", err, text)
+	}
+
+	// The apex NS record is skipped by the parser, the lease record by
+	// the formatter.
+	if len(parsed) != len(records)-1 {
+		t.Fatalf("parsed %d records, want %d:\n%s", len(parsed), len(records)-1, text)
+	}
+	for _, record := range records {
+		if record.Type == "NS" {
+			continue
+		}
+		found := false
+		for _, candidate := range parsed {
+			if candidate.Type == record.Type && candidate.Name == record.Name && candidate.Aux == record.Aux &&
+				recordDataEqual(record.Type, candidate.Data, record.Data) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("record %+v does not survive the round trip, parsed: %+v", record, parsed)
+		}
+	}
+}
+
+func TestZonefileRecordZoneRecord(t *testing.T) {
+	t.Parallel()
+
+	record := zonefileRecord{Type: "MX", Name: "", Data: "mail.example.com.", Aux: 10}.zoneRecord()
+	if record.Type.ValueString() != "MX" || record.Name.ValueString() != "" ||
+		record.Data.ValueString() != "mail.example.com." || record.Aux.ValueInt64() != 10 {
+		t.Errorf("unexpected record: %+v", record)
+	}
+	if record.Name.IsNull() {
+		t.Error("apex name is null, want empty string")
+	}
+}
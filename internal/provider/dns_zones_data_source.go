@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsZonesDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsZonesDataSource{}
+)
+
+// NewDNSZonesDataSource is a helper function to simplify the provider implementation.
+func NewDNSZonesDataSource() datasource.DataSource {
+	return &dnsZonesDataSource{}
+}
+
+// dnsZonesDataSource is the data source implementation.
+type dnsZonesDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// dnsZonesDataSourceModel maps the data source schema data.
+type dnsZonesDataSourceModel struct {
+	ZoneHosts []types.String      `tfsdk:"zone_hosts"`
+	Zones     []dnsZoneEntryModel `tfsdk:"zones"`
+}
+
+// dnsZoneEntryModel maps a zone returned by the data source.
+type dnsZoneEntryModel struct {
+	ZoneHost   types.String   `tfsdk:"zone_host"`
+	Subdomains []types.String `tfsdk:"subdomains"`
+}
+
+// Metadata returns the data source type name.
+func (d *dnsZonesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zones"
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsZonesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the zones of the KAS account, one per domain, so modules can iterate zones without hardcoding them.",
+		Attributes: map[string]schema.Attribute{
+			"zone_hosts": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the zones, sorted, for use with `for_each`.",
+			},
+			"zones": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"zone_host": schema.StringAttribute{
+							Computed: true,
+						},
+						"subdomains": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The subdomains of the account whose records belong to the zone.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsZonesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	domains, err := d.client.GetDomains(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl Domains",
+			"Could not list the domains of the account: "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	subdomains, err := d.client.GetSubdomains(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl Subdomains",
+			"Could not list the subdomains of the account: "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	zoneHosts := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		zoneHosts = append(zoneHosts, domain.Name)
+	}
+	subdomainNames := make([]string, 0, len(subdomains))
+	for _, subdomain := range subdomains {
+		subdomainNames = append(subdomainNames, subdomain.Name)
+	}
+	sort.Strings(zoneHosts)
+
+	state := dnsZonesDataSourceModel{
+		ZoneHosts: []types.String{},
+		Zones:     []dnsZoneEntryModel{},
+	}
+	byZone := subdomainsByZone(zoneHosts, subdomainNames)
+	for _, zoneHost := range zoneHosts {
+		entry := dnsZoneEntryModel{
+			ZoneHost:   types.StringValue(zoneHost),
+			Subdomains: []types.String{},
+		}
+		for _, subdomain := range byZone[zoneHost] {
+			entry.Subdomains = append(entry.Subdomains, types.StringValue(subdomain))
+		}
+		state.ZoneHosts = append(state.ZoneHosts, entry.ZoneHost)
+		state.Zones = append(state.Zones, entry)
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// subdomainsByZone assigns each subdomain to the zone it belongs to, the
+// longest zone name it ends in, keyed by zone. Subdomains of domains outside
+// the account are left out. The subdomains of a zone are sorted.
+func subdomainsByZone(zoneHosts, subdomains []string) map[string][]string {
+	byZone := map[string][]string{}
+	for _, subdomain := range subdomains {
+		fqdn := normalizeHostname(subdomain)
+		zone := ""
+		for _, zoneHost := range zoneHosts {
+			suffix := normalizeHostname(zoneHost)
+			if strings.HasSuffix(fqdn, "."+suffix) && len(zoneHost) > len(zone) {
+				zone = zoneHost
+			}
+		}
+		if zone != "" {
+			byZone[zone] = append(byZone[zone], subdomain)
+		}
+	}
+	for _, names := range byZone {
+		sort.Strings(names)
+	}
+	return byZone
+}
+
+func (d *dnsZonesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubdomainsByZone(t *testing.T) {
+	t.Parallel()
+
+	got := subdomainsByZone(
+		[]string{"example.com", "shop.example.com", "example.org"},
+		[]string{"www.example.com", "api.shop.example.com", "Blog.Example.com", "www.other.net", "notexample.com"},
+	)
+	want := map[string][]string{
+		"example.com":      {"Blog.Example.com", "www.example.com"},
+		"shop.example.com": {"api.shop.example.com"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("subdomainsByZone() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Lease is an account lease of a run, for tests racing several runs.
+type Lease struct {
+	data *allinklProviderData
+}
+
+// NewLease returns the lease of a run named holder in zone, talking to KAS
+// through client.
+func NewLease(client *allinkl.Client, zone, holder string, duration time.Duration) (*Lease, error) {
+	lease, err := newAccountLease(leaseModel{
+		Zone:     types.StringValue(zone),
+		Holder:   types.StringValue(holder),
+		Duration: types.StringValue(duration.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Lease{data: &allinklProviderData{Client: client, Lease: lease}}, nil
+}
+
+// Acquire takes or renews the lease.
+func (l *Lease) Acquire(ctx context.Context) diag.Diagnostics {
+	return l.data.acquireLease(ctx)
+}
+
+// SetDeleteWaitTimeout sets how long deleted records are waited for if the
+// delete has no timeout, until the returned function restores it.
+func SetDeleteWaitTimeout(timeout time.Duration) (restore func()) {
+	previous := defaultDeleteWaitTimeout
+	defaultDeleteWaitTimeout = timeout
+	return func() { defaultDeleteWaitTimeout = previous }
+}
+
+// SetDeletePollInterval sets the delay between two checks whether a deleted
+// record is gone, until the returned function restores it.
+func SetDeletePollInterval(interval time.Duration) (restore func()) {
+	previous := deletePollInterval
+	deletePollInterval = interval
+	return func() { deletePollInterval = previous }
+}
+
+// SetPropagationPollInterval sets the delay between two propagation checks,
+// until the returned function restores it.
+func SetPropagationPollInterval(interval time.Duration) (restore func()) {
+	previous := propagationPollInterval
+	propagationPollInterval = interval
+	return func() { propagationPollInterval = previous }
+}
+
+// SetDefaultNameservers sets the nameservers queried when none are
+// configured, until the returned function restores them.
+func SetDefaultNameservers(nameservers ...string) (restore func()) {
+	previous := defaultNameservers
+	defaultNameservers = nameservers
+	return func() { defaultNameservers = previous }
+}
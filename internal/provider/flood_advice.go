@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// floodAdviceThreshold is the cumulative flood wait after which the provider
+// suggests tuning the request rate.
+const floodAdviceThreshold = time.Minute
+
+// requestTimeoutHint is the time a queued request should at most wait for
+// the requests ahead of it.
+const requestTimeoutHint = 30 * time.Second
+
+// defaultParallelism is the default of terraform apply -parallelism.
+const defaultParallelism = 10
+
+// floodAdvisor sums up the flood delays the client waited for during a run
+// and suggests settings reducing them once they add up.
+type floodAdvisor struct {
+	mu      sync.Mutex
+	total   time.Duration
+	waits   int
+	longest time.Duration
+	advised bool
+}
+
+// observe records a client event.
+func (a *floodAdvisor) observe(event allinkl.Event) {
+	if event.Kind != allinkl.EventFloodDelay {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.total += event.Wait
+	a.waits++
+	if event.Wait > a.longest {
+		a.longest = event.Wait
+	}
+}
+
+// advise adds a warning suggesting -parallelism and min_request_interval
+// values the first time the cumulative flood wait exceeds the threshold.
+func (a *floodAdvisor) advise(diags *diag.Diagnostics) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.advised || a.total < floodAdviceThreshold {
+		return
+	}
+	a.advised = true
+
+	// Requests queue behind each other's flood delays; keep the queue short
+	// enough for the last request to start within the request timeout.
+	interval := a.longest.Round(100 * time.Millisecond)
+	if interval < a.longest {
+		interval += 100 * time.Millisecond
+	}
+	parallelism := defaultParallelism
+	if interval > 0 {
+		parallelism = min(defaultParallelism, max(1, int(requestTimeoutHint/interval)))
+	}
+
+	diags.AddWarning(
+		"AllInkl Flood Delays Slowing Down Apply",
+		fmt.Sprintf("The provider waited %s in total for KAS flood delays across %d requests, at most %s at a time. "+
+			"KAS processes the requests of an account one after another, so running more of them in parallel only makes them queue.\n\n"+
+			"Consider running terraform apply with -parallelism=%d and setting min_request_interval = %q in the provider configuration, "+
+			"so requests are spaced out as KAS expects instead of waiting for each other.",
+			a.total.Round(time.Second), a.waits, a.longest.Round(100*time.Millisecond), parallelism, interval.String()),
+	)
+}
+
+// appendFloodAdvice adds the flood delay advice to diags once the flood
+// delays of the run add up.
+func (d *allinklProviderData) appendFloodAdvice(diags *diag.Diagnostics) {
+	if d == nil || d.FloodAdvisor == nil {
+		return
+	}
+	d.FloodAdvisor.advise(diags)
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestFloodAdvisor(t *testing.T) {
+	t.Parallel()
+
+	var advisor floodAdvisor
+	var diags diag.Diagnostics
+
+	advisor.observe(allinkl.Event{Kind: allinkl.EventRetry, Wait: time.Hour})
+	advisor.observe(allinkl.Event{Kind: allinkl.EventFloodDelay, Wait: 4950 * time.Millisecond})
+	advisor.advise(&diags)
+	if len(diags) != 0 {
+		t.Fatalf("advice below threshold: %v", diags)
+	}
+
+	for i := 0; i < 14; i++ {
+		advisor.observe(allinkl.Event{Kind: allinkl.EventFloodDelay, Wait: 4 * time.Second})
+	}
+	advisor.advise(&diags)
+	if len(diags) != 1 {
+		t.Fatalf("got %d diagnostics, want 1", len(diags))
+	}
+	detail := diags[0].Detail()
+	for _, want := range []string{"-parallelism=6", `min_request_interval = "5s"`, "15 requests"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("advice does not contain %q: %s", want, detail)
+		}
+	}
+
+	advisor.advise(&diags)
+	if len(diags) != 1 {
+		t.Errorf("advice repeated, got %d diagnostics", len(diags))
+	}
+}
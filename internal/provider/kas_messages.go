@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+)
+
+// Supported values of the provider locale attribute.
+const (
+	localeEnglish = "en"
+	localeGerman  = "de"
+)
+
+// kasMessages translates the most common KAS fault and ReturnString codes,
+// keyed by code and locale.
+var kasMessages = map[string]map[string]string{
+	"flood_protection": {
+		localeEnglish: "KAS rejected the request because requests were sent too quickly.",
+		localeGerman:  "KAS hat die Anfrage abgelehnt, da Anfragen zu schnell gesendet wurden.",
+	},
+	"kas_password_incorrect": {
+		localeEnglish: "The KAS password is incorrect.",
+		localeGerman:  "Das KAS-Passwort ist falsch.",
+	},
+	"kas_login_incorrect": {
+		localeEnglish: "The KAS login does not exist.",
+		localeGerman:  "Der KAS-Login existiert nicht.",
+	},
+	"kas_auth_data_incorrect": {
+		localeEnglish: "The KAS session token is invalid or has expired.",
+		localeGerman:  "Das KAS-Sitzungstoken ist ungültig oder abgelaufen.",
+	},
+	"zone_not_found": {
+		localeEnglish: "The zone is not managed by KAS for this account.",
+		localeGerman:  "Die Zone wird für diesen Account nicht von KAS verwaltet.",
+	},
+	"zone_syntax_incorrect": {
+		localeEnglish: "The zone name is not a valid domain name.",
+		localeGerman:  "Der Zonenname ist kein gültiger Domainname.",
+	},
+	"record_id_not_found": {
+		localeEnglish: "The record does not exist.",
+		localeGerman:  "Der Eintrag existiert nicht.",
+	},
+	"record_id_syntax_incorrect": {
+		localeEnglish: "The record ID is not a valid KAS record ID.",
+		localeGerman:  "Die Eintrags-ID ist keine gültige KAS-Eintrags-ID.",
+	},
+	"record_name_syntax_incorrect": {
+		localeEnglish: "The record name is not valid.",
+		localeGerman:  "Der Name des Eintrags ist ungültig.",
+	},
+	"record_type_syntax_incorrect": {
+		localeEnglish: "The record type is not supported by KAS.",
+		localeGerman:  "Der Eintragstyp wird von KAS nicht unterstützt.",
+	},
+	"record_data_syntax_incorrect": {
+		localeEnglish: "The record data is not valid for the record type.",
+		localeGerman:  "Die Daten des Eintrags sind für den Eintragstyp ungültig.",
+	},
+	"record_aux_syntax_incorrect": {
+		localeEnglish: "The record aux value is not valid.",
+		localeGerman:  "Der Aux-Wert des Eintrags ist ungültig.",
+	},
+	"record_already_exists": {
+		localeEnglish: "An identical record already exists in the zone.",
+		localeGerman:  "Ein identischer Eintrag existiert bereits in der Zone.",
+	},
+	"nothing_to_do": {
+		localeEnglish: "KAS reported that there was nothing to change.",
+		localeGerman:  "KAS meldet, dass nichts zu ändern war.",
+	},
+	"in_progress": {
+		localeEnglish: "KAS is still processing a previous change.",
+		localeGerman:  "KAS verarbeitet noch eine vorherige Änderung.",
+	},
+}
+
+// kasActionPermissions describes what the KAS API functions used by the
+// provider allow, keyed by action and locale, for permission faults.
+var kasActionPermissions = map[string]map[string]string{
+	"get_dns_settings": {
+		localeEnglish: "read DNS records",
+		localeGerman:  "DNS-Einträge zu lesen",
+	},
+	"add_dns_settings": {
+		localeEnglish: "create DNS records",
+		localeGerman:  "DNS-Einträge anzulegen",
+	},
+	"update_dns_settings": {
+		localeEnglish: "change DNS records",
+		localeGerman:  "DNS-Einträge zu ändern",
+	},
+	"delete_dns_settings": {
+		localeEnglish: "delete DNS records",
+		localeGerman:  "DNS-Einträge zu löschen",
+	},
+	"get_domains": {
+		localeEnglish: "list the domains of the account",
+		localeGerman:  "die Domains des Accounts aufzulisten",
+	},
+	"get_subdomains": {
+		localeEnglish: "list the subdomains of the account",
+		localeGerman:  "die Subdomains des Accounts aufzulisten",
+	},
+	"get_accountresources": {
+		localeEnglish: "read the resources of the account package",
+		localeGerman:  "die Ressourcen des Account-Tarifs zu lesen",
+	},
+	"get_mailaccounts": {
+		localeEnglish: "list mail accounts",
+		localeGerman:  "E-Mail-Konten aufzulisten",
+	},
+	"add_mailaccount": {
+		localeEnglish: "create mail accounts",
+		localeGerman:  "E-Mail-Konten anzulegen",
+	},
+	"update_mailaccount": {
+		localeEnglish: "change mail accounts",
+		localeGerman:  "E-Mail-Konten zu ändern",
+	},
+	"delete_mailaccount": {
+		localeEnglish: "delete mail accounts",
+		localeGerman:  "E-Mail-Konten zu löschen",
+	},
+	"get_mailforwards": {
+		localeEnglish: "list mail forwards",
+		localeGerman:  "E-Mail-Weiterleitungen aufzulisten",
+	},
+	"add_mailforward": {
+		localeEnglish: "create mail forwards",
+		localeGerman:  "E-Mail-Weiterleitungen anzulegen",
+	},
+	"update_mailforward": {
+		localeEnglish: "change mail forwards",
+		localeGerman:  "E-Mail-Weiterleitungen zu ändern",
+	},
+	"delete_mailforward": {
+		localeEnglish: "delete mail forwards",
+		localeGerman:  "E-Mail-Weiterleitungen zu löschen",
+	},
+}
+
+// permissionMessage returns the message of a permission fault, naming the
+// KAS API function to enable for the login.
+func permissionMessage(locale string, fault *allinkl.Fault, original string) string {
+	permission, ok := kasActionPermissions[fault.Action][locale]
+
+	if locale == localeGerman {
+		if !ok {
+			permission = "die KAS-API-Funktion " + fault.Action + " zu verwenden"
+		}
+		return fmt.Sprintf("Der KAS-Login hat keine Berechtigung, %s. Aktiviere die API-Funktion %s für diesen Login in den "+
+			"API-Einstellungen des Accounts oder Unteraccounts im KAS. (KAS: %s)", permission, fault.Action, original)
+	}
+	if !ok {
+		permission = "use the KAS API function " + fault.Action
+	}
+	return fmt.Sprintf("The KAS login is not allowed to %s. Enable the API function %s for this login in the API settings "+
+		"of the account or sub-account in the KAS. (KAS: %s)", permission, fault.Action, original)
+}
+
+// translateKASMessage returns the translation of a KAS code for locale,
+// followed by the original text, or the original text if the code is not
+// known.
+func translateKASMessage(locale, code, original string) string {
+	if translations, ok := kasMessages[code]; ok {
+		if message, ok := translations[locale]; ok {
+			return fmt.Sprintf("%s (KAS: %s)", message, original)
+		}
+	}
+	return original
+}
+
+// kasErrorMessage returns the message of err for diagnostics, translating
+// KAS faults into the configured locale.
+func (d *allinklProviderData) kasErrorMessage(err error) string {
+	var fault *allinkl.Fault
+	if !errors.As(err, &fault) {
+		return err.Error()
+	}
+	if allinkl.IsPermissionDenied(err) {
+		return permissionMessage(d.locale(), fault, err.Error())
+	}
+	return translateKASMessage(d.locale(), fault.Message, err.Error())
+}
+
+// kasReturnString returns a KAS ReturnString for diagnostics, translated into
+// the configured locale.
+func (d *allinklProviderData) kasReturnString(returnString string) string {
+	return translateKASMessage(d.locale(), returnString, returnString)
+}
+
+func (d *allinklProviderData) locale() string {
+	if d == nil || d.Locale == "" {
+		return localeEnglish
+	}
+	return d.Locale
+}
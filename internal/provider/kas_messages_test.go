@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+)
+
+func TestKASErrorMessagePermissionDenied(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("request failed: %w", &allinkl.Fault{Code: "SOAP-ENV:Server", Message: "kas_action_not_allowed", Actor: "KasApi", Action: "delete_dns_settings"})
+
+	testCases := map[string][]string{
+		localeEnglish: {"not allowed to delete DNS records", "API function delete_dns_settings", "kas_action_not_allowed"},
+		localeGerman:  {"DNS-Einträge zu löschen", "API-Funktion delete_dns_settings", "kas_action_not_allowed"},
+	}
+
+	for locale, wants := range testCases {
+		t.Run(locale, func(t *testing.T) {
+			t.Parallel()
+
+			message := (&allinklProviderData{Locale: locale}).kasErrorMessage(err)
+			for _, want := range wants {
+				if !strings.Contains(message, want) {
+					t.Errorf("message %q does not contain %q", message, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// leaseRecordName is the name of the TXT record holding the account lease.
+const leaseRecordName = "_terraform-lease"
+
+// leaseVersion prefixes the data of lease records.
+const leaseVersion = "v=tflease1"
+
+// defaultLeaseDuration is how long a lease is held after the last change.
+const defaultLeaseDuration = 10 * time.Minute
+
+// accountLease is an advisory lock on the KAS account, held in a TXT record
+// of a zone. Terraform offers no hook at the end of a run, so instead of
+// being released the lease expires duration after the last change.
+type accountLease struct {
+	zone     string
+	holder   string
+	run      string
+	duration time.Duration
+
+	mu       sync.Mutex
+	recordID string
+	expires  time.Time
+}
+
+// leaseModel maps the lease provider block.
+type leaseModel struct {
+	Zone     types.String `tfsdk:"zone"`
+	Holder   types.String `tfsdk:"holder"`
+	Duration types.String `tfsdk:"duration"`
+}
+
+// leaseRecord is the content of a lease record.
+type leaseRecord struct {
+	id      string
+	holder  string
+	run     string
+	expires time.Time
+}
+
+// newAccountLease returns the lease configured by model, held by a run
+// identified at random.
+func newAccountLease(model leaseModel) (*accountLease, error) {
+	var run [8]byte
+	if _, err := rand.Read(run[:]); err != nil {
+		return nil, err
+	}
+
+	lease := &accountLease{
+		zone:     model.Zone.ValueString(),
+		holder:   model.Holder.ValueString(),
+		run:      hex.EncodeToString(run[:]),
+		duration: defaultLeaseDuration,
+	}
+	if lease.holder == "" {
+		hostname, _ := os.Hostname()
+		lease.holder = fmt.Sprintf("%s:%d", hostname, os.Getpid())
+	}
+	if !model.Duration.IsNull() {
+		lease.duration, _ = time.ParseDuration(model.Duration.ValueString())
+	}
+	return lease, nil
+}
+
+// data returns the record data of the lease expiring at expires.
+func (l *accountLease) data(expires time.Time) string {
+	return fmt.Sprintf("%s; holder=%s; run=%s; expires=%s",
+		leaseVersion, sanitizeLeaseField(l.holder), l.run, expires.UTC().Format(time.RFC3339))
+}
+
+// acquire takes or renews the lease before a change to the account. It fails
+// with a diagnostic naming the holder if another run holds the lease.
+//
+// Lease records are never updated: every acquisition adds a fresh record of
+// the run, and a run only deletes its own records and expired ones, which no
+// run holds anymore. Of the unexpired records, the one with the lowest ID
+// holds the lease.
+func (l *accountLease) acquire(ctx context.Context, data *allinklProviderData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.recordID != "" && l.expires.Sub(now) > l.duration/2 {
+		return diags
+	}
+
+	records, diags := readDNSZoneRecords(ctx, data, l.zone)
+	if diags.HasError() {
+		return diags
+	}
+	if other := l.activeOther(leaseRecords(records), now); other != nil {
+		diags.Append(leaseHeldError(l.zone, *other))
+		return diags
+	}
+
+	// Lease records hold whole seconds; round up rather than expire early.
+	expires := now.Add(l.duration + time.Second - 1).Truncate(time.Second)
+	own := allinkl.ReturnInfo{
+		ZoneHost:   toASCIIHostname(l.zone),
+		RecordType: "TXT",
+		RecordName: leaseRecordName,
+		RecordData: l.data(expires),
+	}
+	id, err := data.Client.AddDNSSettings(ctx, allinkl.DNSRequest{
+		ZoneHost:   own.ZoneHost,
+		RecordType: own.RecordType,
+		RecordName: own.RecordName,
+		RecordData: own.RecordData,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error Acquiring AllInkl Account Lease",
+			fmt.Sprintf("Could not write the lease record %s.%s: %s", leaseRecordName, l.zone, data.kasErrorMessage(err)),
+		)
+		return diags
+	}
+	own.ID = id
+
+	// Another run may have written its lease at the same time; the loser
+	// deletes the record it just wrote.
+	records, diags = readDNSZoneRecords(ctx, data, l.zone)
+	if diags.HasError() {
+		diags.Append(deleteDNSRecord(ctx, data, own)...)
+		return diags
+	}
+	leases := leaseRecords(records)
+	if holder := activeLease(leases, now); holder == nil || holder.run != l.run {
+		diags.Append(deleteDNSRecord(ctx, data, own)...)
+		if holder == nil {
+			diags.AddError(
+				"Error Acquiring AllInkl Account Lease",
+				fmt.Sprintf("The lease record %s.%s was not listed after writing it.", leaseRecordName, l.zone),
+			)
+		} else {
+			diags.Append(leaseHeldError(l.zone, *holder))
+		}
+		return diags
+	}
+
+	// Remove the previous record of the run and expired leases, so the zone
+	// does not collect stale records. Another run may remove the same
+	// expired records; deleteDNSRecord ignores records already gone.
+	for _, lease := range leases {
+		if lease.id != id && (lease.run == l.run || !lease.expires.After(now)) {
+			diags.Append(deleteDNSRecord(ctx, data, allinkl.ReturnInfo{
+				ID:         lease.id,
+				ZoneHost:   own.ZoneHost,
+				RecordType: own.RecordType,
+				RecordName: own.RecordName,
+			})...)
+		}
+	}
+
+	tflog.Debug(ctx, "Acquired AllInkl account lease", map[string]any{
+		"zone":    l.zone,
+		"holder":  l.holder,
+		"expires": expires.UTC().Format(time.RFC3339),
+	})
+	l.recordID = id
+	l.expires = expires
+	return diags
+}
+
+// activeLease returns the unexpired lease with the lowest ID, which holds
+// the lease, if any. leases must be ordered by ID.
+func activeLease(leases []leaseRecord, now time.Time) *leaseRecord {
+	for i, lease := range leases {
+		if lease.expires.After(now) {
+			return &leases[i]
+		}
+	}
+	return nil
+}
+
+// activeOther returns the unexpired lease of another run, if any.
+func (l *accountLease) activeOther(leases []leaseRecord, now time.Time) *leaseRecord {
+	for i, lease := range leases {
+		if lease.run != l.run && lease.expires.After(now) {
+			return &leases[i]
+		}
+	}
+	return nil
+}
+
+// leaseHeldError returns the error reported while another run holds the
+// lease.
+func leaseHeldError(zone string, lease leaseRecord) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"AllInkl Account Locked",
+		fmt.Sprintf("Another Terraform run holds the account lease in %s.%s: %s, until %s. "+
+			"Wait for it to finish, or delete the lease record if the run was aborted.",
+			leaseRecordName, zone, lease.holder, lease.expires.UTC().Format(time.RFC3339)),
+	)
+}
+
+// leaseRecords returns the lease records among records, ordered by ID.
+// Records with unparsable content are treated as expired leases.
+func leaseRecords(records []allinkl.ReturnInfo) []leaseRecord {
+	var leases []leaseRecord
+	for _, record := range records {
+		if isLeaseRecord(record) {
+			lease := parseLeaseData(unquoteTXT(record.RecordData))
+			lease.id = fmt.Sprint(record.ID)
+			leases = append(leases, lease)
+		}
+	}
+	sort.SliceStable(leases, func(i, j int) bool {
+		return lessRecordID(leases[i].id, leases[j].id)
+	})
+	return leases
+}
+
+// isLeaseRecord reports whether record holds an account lease.
+func isLeaseRecord(record allinkl.ReturnInfo) bool {
+	return strings.EqualFold(record.RecordType, "TXT") && hostnameEqual(record.RecordName, leaseRecordName)
+}
+
+// parseLeaseData parses the data of a lease record.
+func parseLeaseData(data string) leaseRecord {
+	var lease leaseRecord
+	fields := strings.Split(data, ";")
+	if strings.TrimSpace(fields[0]) != leaseVersion {
+		return lease
+	}
+	for _, field := range fields[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch key {
+		case "holder":
+			lease.holder = value
+		case "run":
+			lease.run = value
+		case "expires":
+			lease.expires, _ = time.Parse(time.RFC3339, value)
+		}
+	}
+	return lease
+}
+
+// sanitizeLeaseField removes the characters separating lease fields.
+func sanitizeLeaseField(value string) string {
+	return strings.NewReplacer(";", "", "\"", "").Replace(value)
+}
+
+// lessRecordID orders numeric KAS record IDs numerically.
+func lessRecordID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// acquireLease takes or renews the account lease if one is configured.
+func (d *allinklProviderData) acquireLease(ctx context.Context) diag.Diagnostics {
+	if d == nil || d.Lease == nil {
+		return nil
+	}
+	return d.Lease.acquire(ctx, d)
+}
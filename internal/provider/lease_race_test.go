@@ -0,0 +1,133 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/ViMaSter/terraform-provider-allinkl/internal/provider"
+)
+
+func TestAccountLeaseRace(t *testing.T) {
+	server := newServer(t)
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+
+	// An expired lease of an aborted run, removed by the winner.
+	server.AddRecord(allinkltest.Record{
+		Zone:       "example.com",
+		Name:       "_terraform-lease",
+		Type:       "TXT",
+		Data:       "v=tflease1; holder=aborted; run=0000; expires=2020-01-01T00:00:00Z",
+		Changeable: true,
+	})
+
+	const runs = 5
+	var leases []*provider.Lease
+	for i := 0; i < runs; i++ {
+		lease, err := provider.NewLease(client, "example.com", fmt.Sprintf("run-%d", i), time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		leases = append(leases, lease)
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	acquired := make([]bool, runs)
+	for i, lease := range leases {
+		wg.Add(1)
+		go func(i int, lease *provider.Lease) {
+			defer wg.Done()
+			<-start
+			diags := lease.Acquire(context.Background())
+			for _, d := range diags {
+				if d.Summary() != "AllInkl Account Locked" {
+					t.Errorf("run-%d: unexpected diagnostic %s: %s", i, d.Summary(), d.Detail())
+				}
+			}
+			acquired[i] = !diags.HasError()
+		}(i, lease)
+	}
+	close(start)
+	wg.Wait()
+
+	var winners []string
+	for i, ok := range acquired {
+		if ok {
+			winners = append(winners, fmt.Sprintf("run-%d", i))
+		}
+	}
+	if len(winners) != 1 {
+		t.Fatalf("lease acquired by %v, want exactly one run", winners)
+	}
+
+	records := leaseRecordData(server)
+	if len(records) != 1 || !strings.Contains(records[0], "holder="+winners[0]+";") {
+		t.Errorf("lease records %q, want only the lease of %s", records, winners[0])
+	}
+}
+
+func TestAccountLeaseRenewal(t *testing.T) {
+	server := newServer(t)
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+
+	// The lease expires within a second, so the second acquisition renews it.
+	lease, err := provider.NewLease(client, "example.com", "ci", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			time.Sleep(1100 * time.Millisecond)
+		}
+		if diags := lease.Acquire(context.Background()); diags.HasError() {
+			t.Fatalf("acquisition %d: %v", i, diags)
+		}
+	}
+
+	if records := leaseRecordData(server); len(records) != 1 {
+		t.Errorf("lease records %q, want only the latest", records)
+	}
+
+}
+
+func TestAccountLeaseHeld(t *testing.T) {
+	server := newServer(t)
+	client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+
+	other, err := provider.NewLease(client, "example.com", "other", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := other.Acquire(context.Background()); diags.HasError() {
+		t.Fatalf("other run: %v", diags)
+	}
+
+	lease, err := provider.NewLease(client, "example.com", "ci", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags := lease.Acquire(context.Background())
+	if !diags.HasError() || diags[0].Summary() != "AllInkl Account Locked" {
+		t.Errorf("acquiring a lease held by another run: %v", diags)
+	}
+	if records := leaseRecordData(server); len(records) != 1 || !strings.Contains(records[0], "holder=other;") {
+		t.Errorf("lease records %q, want only the lease of the other run", records)
+	}
+}
+
+// leaseRecordData returns the data of the lease records of example.com.
+func leaseRecordData(server *allinkltest.Server) []string {
+	var data []string
+	for _, record := range server.Records("example.com") {
+		if record.Name == "_terraform-lease" {
+			data = append(data, record.Data)
+		}
+	}
+	return data
+}
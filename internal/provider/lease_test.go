@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+)
+
+func TestParseLeaseData(t *testing.T) {
+	t.Parallel()
+
+	lease := parseLeaseData("v=tflease1; holder=ci:42; run=abcd; expires=2026-01-02T03:04:05Z")
+	if lease.holder != "ci:42" || lease.run != "abcd" || !lease.expires.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected lease %+v", lease)
+	}
+
+	if lease := parseLeaseData("something else"); !lease.expires.IsZero() {
+		t.Errorf("foreign record parsed as lease %+v", lease)
+	}
+}
+
+func TestAccountLeaseActiveOther(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	lease := &accountLease{holder: "team-a", run: "aaaa", duration: time.Hour}
+	other := &accountLease{holder: "team-b", run: "bbbb", duration: time.Hour}
+
+	records := []allinkl.ReturnInfo{
+		{ID: "1010", RecordType: "TXT", RecordName: leaseRecordName, RecordData: other.data(now.Add(time.Minute))},
+		{ID: "999", RecordType: "TXT", RecordName: leaseRecordName, RecordData: lease.data(now.Add(time.Minute))},
+		{ID: "1001", RecordType: "TXT", RecordName: "www", RecordData: other.data(now.Add(time.Minute))},
+	}
+
+	leases := leaseRecords(records)
+	if len(leases) != 2 || leases[0].id != "999" || leases[1].id != "1010" {
+		t.Fatalf("unexpected lease records %+v", leases)
+	}
+
+	if held := lease.activeOther(leases, now); held == nil || held.holder != "team-b" {
+		t.Errorf("activeOther = %+v, want the lease of team-b", held)
+	}
+	if held := lease.activeOther(leases, now.Add(2*time.Minute)); held != nil {
+		t.Errorf("activeOther = %+v after expiry, want nil", held)
+	}
+	if held := other.activeOther(leases[1:], now); held != nil {
+		t.Errorf("activeOther = %+v for the own lease, want nil", held)
+	}
+}
+
+func TestManagedRecordsSkipsLease(t *testing.T) {
+	t.Parallel()
+
+	lease := &accountLease{holder: "ci", run: "abcd", duration: time.Hour}
+	records := []allinkl.ReturnInfo{
+		{ID: "1", RecordType: "NS", RecordName: "", RecordData: "ns5.kasserver.com.", Changeable: "N"},
+		{ID: "2", RecordType: "A", RecordName: "www", RecordData: "192.0.2.1", Changeable: "Y"},
+		{ID: "3", RecordType: "TXT", RecordName: leaseRecordName, RecordData: lease.data(time.Now()), Changeable: "Y"},
+		{ID: "4", RecordType: "TXT", RecordName: "_terraform-lease.www", RecordData: "v=spf1 -all", Changeable: "Y"},
+	}
+
+	managed := managedRecords(records)
+	if len(managed) != 2 || managed[0].ID != "2" || managed[1].ID != "4" {
+		t.Errorf("managedRecords = %+v, want the records 2 and 4", managed)
+	}
+}
@@ -0,0 +1,420 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &mailAccountResource{}
+	_ resource.ResourceWithConfigure   = &mailAccountResource{}
+	_ resource.ResourceWithImportState = &mailAccountResource{}
+	_ resource.ResourceWithModifyPlan  = &mailAccountResource{}
+
+	_ resource.ResourceWithConfigValidators = &mailAccountResource{}
+	_ resource.ConfigValidator              = mailAccountQuotaValidator{}
+)
+
+// NewMailAccountResource is a helper function to simplify the provider implementation.
+func NewMailAccountResource() resource.Resource {
+	return &mailAccountResource{}
+}
+
+// mailAccountResource is the resource implementation.
+type mailAccountResource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// mailAccountResourceModel maps the resource schema data.
+type mailAccountResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	LocalPart types.String `tfsdk:"local_part"`
+	Domain    types.String `tfsdk:"domain"`
+	Password  types.String `tfsdk:"password"`
+	Quota     types.Int64  `tfsdk:"quota"`
+	Address   types.String `tfsdk:"address"`
+}
+
+// Metadata returns the resource type name.
+func (r *mailAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mail_account"
+}
+
+// Schema defines the schema for the resource.
+func (r *mailAccountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a mailbox of the account. The mail account is imported by its KAS login, e.g. `m0123456`; " +
+			"KAS does not return passwords, so the next apply after an import sets `password`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The KAS login of the mail account, e.g. `m0123456`, used for POP3, IMAP and SMTP.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The part of the address before the `@`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain of the address. It must be a domain of the account.",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfZoneChanged(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+			},
+			"quota": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The mailbox size in MB, `-1` if unlimited. Defaults to the size KAS assigns. A mail account always has a mailbox; use `allinkl_mail_forward` for an address that only forwards mail.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The address of the mail account, combining `local_part` and `domain`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the resource-level validators.
+func (r *mailAccountResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		mailAccountQuotaValidator{},
+	}
+}
+
+// ModifyPlan checks the mail account quota of the package before creating a
+// mail account, if quota_preflight is enabled.
+func (r *mailAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan mailAccountResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.checkQuota(ctx, "mail_account", "mail accounts", mailAccountQuotaKey(plan))...)
+}
+
+// mailAccountQuotaKey identifies a planned mail account among the quota
+// reservations by its address. Addresses only known after apply are unique.
+func mailAccountQuotaKey(plan mailAccountResourceModel) string {
+	if plan.LocalPart.IsUnknown() || plan.Domain.IsUnknown() {
+		return fmt.Sprintf("unknown %p", &plan)
+	}
+	return strings.ToLower(mailAddress(plan.LocalPart.ValueString(), toKASMailDomain(plan.Domain.ValueString())))
+}
+
+func (r *mailAccountResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+// Create creates the mail account and sets the initial Terraform state.
+func (r *mailAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan mailAccountResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer r.providerData.releaseQuota("mail_account", mailAccountQuotaKey(plan))
+
+	login, err := r.client.AddMailAccount(ctx, allinkl.MailAccountRequest{
+		Password:   plan.Password.ValueString(),
+		LocalPart:  plan.LocalPart.ValueString(),
+		DomainPart: toKASMailDomain(plan.Domain.ValueString()),
+		Quota:      plan.Quota.ValueInt64(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating AllInkl Mail Account",
+			"Could not create mail account "+mailAddress(plan.LocalPart.ValueString(), plan.Domain.ValueString())+": "+r.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created AllInkl mail account", map[string]any{"mail_login": login})
+
+	plan.ID = types.StringValue(login)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+
+	account, diags := r.readMailAccount(ctx, login)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if account == nil {
+		resp.Diagnostics.AddError(
+			"Error Creating AllInkl Mail Account",
+			"KAS created mail account "+login+" but does not list it.",
+		)
+		return
+	}
+	plan = refreshMailAccountModel(plan, *account)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *mailAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	var state mailAccountResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account, diags := r.readMailAccount(ctx, state.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if account == nil {
+		tflog.Warn(ctx, "AllInkl mail account not found, removing from state", map[string]any{
+			"mail_login": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, refreshMailAccountModel(state, *account))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update changes the password and quota of the mail account.
+func (r *mailAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state, plan mailAccountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	update := allinkl.MailAccountRequest{
+		Login: state.ID.ValueString(),
+		Quota: plan.Quota.ValueInt64(),
+	}
+	if !plan.Password.Equal(state.Password) {
+		update.NewPassword = plan.Password.ValueString()
+	}
+	if err := r.client.UpdateMailAccount(ctx, update); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating AllInkl Mail Account",
+			"Could not update mail account "+state.ID.ValueString()+": "+r.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	account, diags := r.readMailAccount(ctx, state.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if account != nil {
+		plan = refreshMailAccountModel(plan, *account)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the mail account and removes the Terraform state on success.
+func (r *mailAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state mailAccountResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteMailAccount(ctx, state.ID.ValueString())
+	if err != nil && !allinkl.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting AllInkl Mail Account",
+			"Could not delete mail account "+state.ID.ValueString()+": "+r.providerData.kasErrorMessage(err),
+		)
+	}
+}
+
+// ImportState imports a mail account by its KAS login.
+func (r *mailAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	login := strings.TrimSpace(req.ID)
+	if login == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected the KAS login of the mail account, e.g. m0123456, as import ID.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), login)...)
+}
+
+// readMailAccount returns the mail account with the given login, or nil if
+// it does not exist.
+func (r *mailAccountResource) readMailAccount(ctx context.Context, login string) (*allinkl.MailAccount, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	accounts, err := r.client.GetMailAccounts(ctx, login)
+	if allinkl.IsNotFound(err) {
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Reading AllInkl Mail Account",
+			"Could not read mail account "+login+": "+r.providerData.kasErrorMessage(err),
+		)
+		return nil, diags
+	}
+
+	for i, account := range accounts {
+		if account.Login == login {
+			return &accounts[i], diags
+		}
+	}
+	return nil, diags
+}
+
+// refreshMailAccountModel returns known updated with the remote values of
+// account. local_part and domain are kept unless the address changed.
+func refreshMailAccountModel(known mailAccountResourceModel, account allinkl.MailAccount) mailAccountResourceModel {
+	refreshed := known
+	refreshed.ID = types.StringValue(account.Login)
+	refreshed.Quota = types.Int64Value(account.QuotaMB())
+
+	address := account.Address()
+	if !strings.EqualFold(address, mailAddress(known.LocalPart.ValueString(), toKASMailDomain(known.Domain.ValueString()))) {
+		localPart, domain, _ := strings.Cut(address, "@")
+		refreshed.LocalPart = types.StringValue(localPart)
+		refreshed.Domain = types.StringValue(domain)
+	}
+	refreshed.Address = types.StringValue(address)
+	return refreshed
+}
+
+// mailAddress returns the address of localPart at domain.
+func mailAddress(localPart, domain string) string {
+	return localPart + "@" + domain
+}
+
+// toKASMailDomain converts the domain of an address to the form KAS reports:
+// internationalized labels are converted to punycode and a trailing dot is
+// removed.
+func toKASMailDomain(domain string) string {
+	return strings.TrimSuffix(toASCIIHostname(domain), ".")
+}
+
+// mailAccountQuotaValidator rejects quotas that do not describe a mailbox.
+// KAS has no forward-only mail accounts and treats a quota of 0 as the
+// default size of the package, so configuring a forward-only address as a
+// mail account with quota 0 would silently create a full mailbox.
+type mailAccountQuotaValidator struct{}
+
+func (v mailAccountQuotaValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v mailAccountQuotaValidator) MarkdownDescription(_ context.Context) string {
+	return "quota must be a positive mailbox size or -1 for unlimited; forward-only addresses are allinkl_mail_forward resources"
+}
+
+func (v mailAccountQuotaValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var quota types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("quota"), &quota)...)
+	if resp.Diagnostics.HasError() || quota.IsNull() || quota.IsUnknown() {
+		return
+	}
+
+	switch {
+	case quota.ValueInt64() == 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("quota"),
+			"Forward-Only Mail Account",
+			"A mail account always has a mailbox; KAS treats a quota of 0 as the default mailbox size of the package. "+
+				"Use an allinkl_mail_forward resource for an address that only forwards mail, or set quota to the mailbox size in MB.",
+		)
+	case quota.ValueInt64() < -1:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("quota"),
+			"Invalid Mailbox Quota",
+			fmt.Sprintf("quota must be the mailbox size in MB or -1 for unlimited, got %d.", quota.ValueInt64()),
+		)
+	}
+}
@@ -0,0 +1,173 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// checkMailAccounts returns a check comparing the mail accounts of server,
+// formatted as "<address> <quota> <password>", with want in order.
+func checkMailAccounts(server *allinkltest.Server, want ...string) func(*terraform.State) error {
+	return func(*terraform.State) error {
+		var got []string
+		for _, account := range server.MailAccounts() {
+			got = append(got, fmt.Sprintf("%s %d %s", account.Address, account.Quota, account.Password))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			return fmt.Errorf("mail accounts = %q, want %q", got, want)
+		}
+		return nil
+	}
+}
+
+func TestMailAccountResource(t *testing.T) {
+	server := newServer(t)
+
+	config := func(localPart, password, quota string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "allinkl_mail_account" "info" {
+  local_part = %q
+  domain     = "example.com"
+  password   = %q
+  quota      = %s
+}
+`, localPart, password, quota)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				// Without a quota the account gets the default of the package.
+				Config: config("info", "secret", "null"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("allinkl_mail_account.info", "id"),
+					resource.TestCheckResourceAttr("allinkl_mail_account.info", "address", "info@example.com"),
+					resource.TestCheckResourceAttr("allinkl_mail_account.info", "quota", fmt.Sprint(allinkltest.DefaultMailQuota)),
+					checkMailAccounts(server, fmt.Sprintf("info@example.com %d secret", allinkltest.DefaultMailQuota)),
+				),
+			},
+			{
+				Config: config("info", "changed", "2048"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("allinkl_mail_account.info", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkMailAccounts(server, "info@example.com 2048 changed"),
+			},
+			{
+				ResourceName:            "allinkl_mail_account.info",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				Config: config("office", "changed", "2048"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("allinkl_mail_account.info", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: checkMailAccounts(server, "office@example.com 2048 changed"),
+			},
+		},
+		CheckDestroy: checkMailAccounts(server),
+	})
+}
+
+func TestMailAccountResourceDeletedOutside(t *testing.T) {
+	server := newServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + mailAccountsConfig("info"),
+				Check:  checkMailAccounts(server, fmt.Sprintf("info@example.com %d secret", allinkltest.DefaultMailQuota)),
+			},
+			{
+				// An account deleted in KAS is created again.
+				PreConfig: func() {
+					client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+					if err := client.DeleteMailAccount(context.Background(), server.MailAccounts()[0].Login); err != nil {
+						t.Fatalf("DeleteMailAccount: %s", err)
+					}
+				},
+				Config: server.ProviderConfig() + mailAccountsConfig("info"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("allinkl_mail_account.info", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkMailAccounts(server, fmt.Sprintf("info@example.com %d secret", allinkltest.DefaultMailQuota)),
+			},
+		},
+		CheckDestroy: checkMailAccounts(server),
+	})
+}
+
+func TestMailAccountResourceRejectsForwardOnly(t *testing.T) {
+	server := newServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_mail_account" "info" {
+  local_part = "info"
+  domain     = "example.com"
+  password   = "secret"
+  quota      = 0
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Forward-Only Mail Account`),
+			},
+		},
+	})
+}
+
+// mailAccountsConfig returns a mail_account resource for each local part.
+func mailAccountsConfig(localParts ...string) string {
+	var config string
+	for _, localPart := range localParts {
+		config += `
+resource "allinkl_mail_account" "` + localPart + `" {
+  local_part = "` + localPart + `"
+  domain     = "example.com"
+  password   = "secret"
+}
+`
+	}
+	return config
+}
+
+func TestMailAccountResourceQuotaPreflight(t *testing.T) {
+	server := newServer(t)
+	server.SetAccountResource("mail_account", 25, 23)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				// Each account fits on its own, but not all three together.
+				Config:      providerConfig(server, `  quota_preflight = true`) + mailAccountsConfig("alice", "bob", "carol"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`quota exceeded: 23/25 mail accounts with 2 more planned`),
+			},
+			{
+				Config: providerConfig(server, `  quota_preflight = true`) + mailAccountsConfig("alice", "bob"),
+				Check:  resource.TestCheckResourceAttr("allinkl_mail_account.alice", "address", "alice@example.com"),
+			},
+		},
+	})
+}
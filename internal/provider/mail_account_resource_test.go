@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRefreshMailAccountModel(t *testing.T) {
+	t.Parallel()
+
+	known := mailAccountResourceModel{
+		ID:        types.StringValue("m0001001"),
+		LocalPart: types.StringValue("Info"),
+		Domain:    types.StringValue("Example.com."),
+		Password:  types.StringValue("secret"),
+		Quota:     types.Int64Unknown(),
+	}
+	account := allinkl.MailAccount{Login: "m0001001", Addresses: "info@example.com, alias@example.com", Quota: "500"}
+
+	refreshed := refreshMailAccountModel(known, account)
+	if refreshed.Address.ValueString() != "info@example.com" {
+		t.Errorf("unexpected address %s", refreshed.Address)
+	}
+	if refreshed.LocalPart.ValueString() != "Info" || refreshed.Domain.ValueString() != "Example.com." {
+		t.Errorf("expected local_part and domain to keep their formatting, got %q and %q",
+			refreshed.LocalPart.ValueString(), refreshed.Domain.ValueString())
+	}
+	if refreshed.Quota.ValueInt64() != 500 || refreshed.Password.ValueString() != "secret" {
+		t.Errorf("unexpected quota %s or password %s", refreshed.Quota, refreshed.Password)
+	}
+
+	// Imported mail accounts only know their login.
+	imported := refreshMailAccountModel(mailAccountResourceModel{ID: types.StringValue("m0001001")}, account)
+	if imported.LocalPart.ValueString() != "info" || imported.Domain.ValueString() != "example.com" ||
+		imported.Address.ValueString() != "info@example.com" {
+		t.Errorf("unexpected imported model %+v", imported)
+	}
+}
+
+func TestMailAccountQuotaValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		quota     tftypes.Value
+		wantError bool
+	}{
+		"default":       {quota: tftypes.NewValue(tftypes.Number, nil)},
+		"mailbox":       {quota: tftypes.NewValue(tftypes.Number, 1024)},
+		"unlimited":     {quota: tftypes.NewValue(tftypes.Number, -1)},
+		"unknown":       {quota: tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+		"forward-only":  {quota: tftypes.NewValue(tftypes.Number, 0), wantError: true},
+		"negative-size": {quota: tftypes.NewValue(tftypes.Number, -5), wantError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateResourceConfig(t, "allinkl_mail_account", func(tftypes.Object) map[string]tftypes.Value {
+				return map[string]tftypes.Value{
+					"local_part": tftypes.NewValue(tftypes.String, "info"),
+					"domain":     tftypes.NewValue(tftypes.String, "example.com"),
+					"password":   tftypes.NewValue(tftypes.String, "secret"),
+					"quota":      testCase.quota,
+				}
+			})
+			if hasError := len(diags) > 0; hasError != testCase.wantError {
+				t.Errorf("diagnostics = %v, want error %t", diags, testCase.wantError)
+			}
+		})
+	}
+}
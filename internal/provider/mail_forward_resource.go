@@ -0,0 +1,430 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &mailForwardResource{}
+	_ resource.ResourceWithConfigure   = &mailForwardResource{}
+	_ resource.ResourceWithImportState = &mailForwardResource{}
+	_ resource.ResourceWithModifyPlan  = &mailForwardResource{}
+)
+
+// NewMailForwardResource is a helper function to simplify the provider implementation.
+func NewMailForwardResource() resource.Resource {
+	return &mailForwardResource{}
+}
+
+// mailForwardResource is the resource implementation.
+type mailForwardResource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// mailForwardResourceModel maps the resource schema data.
+type mailForwardResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	LocalPart types.String   `tfsdk:"local_part"`
+	Domain    types.String   `tfsdk:"domain"`
+	Targets   []types.String `tfsdk:"targets"`
+}
+
+// Metadata returns the resource type name.
+func (r *mailForwardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mail_forward"
+}
+
+// Schema defines the schema for the resource.
+func (r *mailForwardResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a mail forward, an address of the account without a mailbox whose mail is forwarded to other " +
+			"addresses. The mail forward is imported by its address, e.g. `sales@example.com`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The address of the mail forward, combining `local_part` and `domain`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The part of the address before the `@`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain of the address. It must be a domain of the account.",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfZoneChanged(),
+				},
+			},
+			"targets": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The addresses mail is forwarded to.",
+			},
+		},
+	}
+}
+
+// ModifyPlan fails the plan if the planned targets close a forwarding loop
+// with the mail forwards of the account or the other mail forwards planned in
+// the run, e.g. a to b and b back to a. KAS accepts such forwards, but the
+// mail then bounces between them.
+func (r *mailForwardResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	// targets may be unknown until apply when derived from other resources.
+	var targets types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("targets"), &targets)...)
+	if resp.Diagnostics.HasError() || targets.IsUnknown() {
+		return
+	}
+
+	var state, plan mailForwardResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.LocalPart.IsUnknown() || plan.Domain.IsUnknown() {
+		return
+	}
+
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	forwards, err := r.client.GetMailForwards(ctx, "")
+	if err != nil && !allinkl.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Check AllInkl Mail Forward Loops",
+			"Could not read the mail forwards of the account: "+r.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	graph := map[string][]string{}
+	for _, forward := range forwards {
+		graph[strings.ToLower(forward.Address)] = forward.TargetList()
+	}
+	if !state.ID.IsNull() {
+		// A replaced mail forward is deleted first.
+		delete(graph, strings.ToLower(state.ID.ValueString()))
+	}
+	address := strings.ToLower(mailAddress(plan.LocalPart.ValueString(), toKASMailDomain(plan.Domain.ValueString())))
+	var planned []string
+	for _, target := range plan.Targets {
+		if !target.IsUnknown() {
+			planned = append(planned, target.ValueString())
+		}
+	}
+
+	if cycle := r.providerData.MailForwards.plan(graph, address, planned); cycle != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("targets"),
+			"Mail Forward Loop",
+			"Mail to "+address+" would be forwarded in a loop: "+strings.Join(cycle, " -> ")+". "+
+				"KAS accepts the mail forwards, but the mail server bounces the mail once it detects the loop. "+
+				"Remove one of the forwards from the loop.",
+		)
+	}
+}
+
+func (r *mailForwardResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+// Create creates the mail forward and sets the initial Terraform state.
+func (r *mailForwardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan mailForwardResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := toKASMailDomain(plan.Domain.ValueString())
+	address := mailAddress(plan.LocalPart.ValueString(), domain)
+	err := r.client.AddMailForward(ctx, plan.LocalPart.ValueString(), domain, mailForwardTargets(plan.Targets))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating AllInkl Mail Forward",
+			"Could not create mail forward "+address+": "+r.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+	tflog.Info(ctx, "Created AllInkl mail forward", map[string]any{"mail_forward": address})
+
+	plan.ID = types.StringValue(strings.ToLower(address))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *mailForwardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	var state mailForwardResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	forward, diags := r.readMailForward(ctx, state.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if forward == nil {
+		tflog.Warn(ctx, "AllInkl mail forward not found, removing from state", map[string]any{
+			"mail_forward": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, refreshMailForwardModel(state, *forward))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update replaces the targets of the mail forward.
+func (r *mailForwardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state, plan mailForwardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateMailForward(ctx, state.ID.ValueString(), mailForwardTargets(plan.Targets))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating AllInkl Mail Forward",
+			"Could not update mail forward "+state.ID.ValueString()+": "+r.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the mail forward and removes the Terraform state on success.
+func (r *mailForwardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state mailForwardResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteMailForward(ctx, state.ID.ValueString())
+	if err != nil && !allinkl.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting AllInkl Mail Forward",
+			"Could not delete mail forward "+state.ID.ValueString()+": "+r.providerData.kasErrorMessage(err),
+		)
+	}
+}
+
+// ImportState imports a mail forward by its address.
+func (r *mailForwardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	localPart, domain, ok := strings.Cut(strings.TrimSpace(req.ID), "@")
+	if !ok || localPart == "" || domain == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected the address of the mail forward, e.g. sales@example.com, as import ID. Got: "+req.ID,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strings.ToLower(mailAddress(localPart, toKASMailDomain(domain))))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+}
+
+// readMailForward returns the mail forward of address, or nil if it does
+// not exist.
+func (r *mailForwardResource) readMailForward(ctx context.Context, address string) (*allinkl.MailForward, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	forwards, err := r.client.GetMailForwards(ctx, address)
+	if allinkl.IsNotFound(err) {
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Reading AllInkl Mail Forward",
+			"Could not read mail forward "+address+": "+r.providerData.kasErrorMessage(err),
+		)
+		return nil, diags
+	}
+
+	for i, forward := range forwards {
+		if strings.EqualFold(forward.Address, address) {
+			return &forwards[i], diags
+		}
+	}
+	return nil, diags
+}
+
+// refreshMailForwardModel returns known updated with the remote targets of
+// forward. Targets are kept if they only differ in case or order.
+func refreshMailForwardModel(known mailForwardResourceModel, forward allinkl.MailForward) mailForwardResourceModel {
+	refreshed := known
+
+	remote := forward.TargetList()
+	if !sameMailAddresses(mailForwardTargets(known.Targets), remote) {
+		refreshed.Targets = stringValues(remote)
+	}
+	return refreshed
+}
+
+// mailForwardTargets converts the targets of a mail forward to strings.
+func mailForwardTargets(targets []types.String) []string {
+	result := make([]string, 0, len(targets))
+	for _, target := range targets {
+		result = append(result, target.ValueString())
+	}
+	return result
+}
+
+// plannedMailForwards collects the targets of the mail forwards planned in
+// the run. Terraform plans every resource on its own, so a loop closed by
+// forwards created together only shows when their plans are combined.
+type plannedMailForwards struct {
+	mu       sync.Mutex
+	forwards map[string][]string
+}
+
+// plan records the planned targets of address and returns a forwarding loop
+// through address in graph combined with the forwards planned so far, or nil
+// if there is none.
+func (p *plannedMailForwards) plan(graph map[string][]string, address string, targets []string) []string {
+	graph[address] = targets
+	if p == nil {
+		return mailForwardCycle(graph, address)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.forwards == nil {
+		p.forwards = map[string][]string{}
+	}
+	p.forwards[address] = targets
+	for planned, targets := range p.forwards {
+		graph[planned] = targets
+	}
+	return mailForwardCycle(graph, address)
+}
+
+// mailForwardCycle returns the addresses of a forwarding loop leading from
+// start back to start, e.g. [a b a], or nil if there is none. graph maps the
+// lower case address of every mail forward to its targets.
+func mailForwardCycle(graph map[string][]string, start string) []string {
+	visited := map[string]bool{}
+
+	var walk func(address string, trail []string) []string
+	walk = func(address string, trail []string) []string {
+		trail = append(trail, address)
+		for _, target := range graph[address] {
+			target = strings.ToLower(strings.TrimSpace(target))
+			if target == start {
+				return append(trail, start)
+			}
+			if visited[target] {
+				continue
+			}
+			visited[target] = true
+			if cycle := walk(target, trail); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk(start, nil)
+}
+
+// sameMailAddresses reports whether a and b hold the same addresses,
+// regardless of case and order.
+func sameMailAddresses(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := map[string]int{}
+	for _, address := range a {
+		count[strings.ToLower(address)]++
+	}
+	for _, address := range b {
+		count[strings.ToLower(address)]--
+	}
+	for _, n := range count {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
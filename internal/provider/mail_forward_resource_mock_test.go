@@ -0,0 +1,93 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestMailForwardResource(t *testing.T) {
+	server := newServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_mail_forward" "sales" {
+  local_part = "sales"
+  domain     = "example.com"
+  targets    = ["alice@example.net"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("allinkl_mail_forward.sales", "id", "sales@example.com"),
+					checkMailForwards(server, "sales@example.com -> [alice@example.net]"),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_mail_forward" "sales" {
+  local_part = "sales"
+  domain     = "example.com"
+  targets    = ["alice@example.net", "bob@example.net"]
+}
+`,
+				Check: checkMailForwards(server, "sales@example.com -> [alice@example.net bob@example.net]"),
+			},
+			{
+				ResourceName:      "allinkl_mail_forward.sales",
+				ImportState:       true,
+				ImportStateId:     "sales@example.com",
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: checkMailForwards(server),
+	})
+}
+
+func TestMailForwardResourceLoopInConfiguration(t *testing.T) {
+	server := newServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_mail_forward" "a" {
+  local_part = "a"
+  domain     = "example.com"
+  targets    = ["b@example.com"]
+}
+
+resource "allinkl_mail_forward" "b" {
+  local_part = "b"
+  domain     = "example.com"
+  targets    = ["a@example.com"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Mail Forward Loop`),
+			},
+		},
+	})
+}
+
+// checkMailForwards returns a check comparing the mail forwards of server,
+// formatted as "<address> -> [<targets>]", with want.
+func checkMailForwards(server *allinkltest.Server, want ...string) func(*terraform.State) error {
+	return func(*terraform.State) error {
+		var got []string
+		for _, forward := range server.MailForwards() {
+			got = append(got, fmt.Sprintf("%s -> %v", forward.Address, forward.Targets))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			return fmt.Errorf("mail forwards = %q, want %q", got, want)
+		}
+		return nil
+	}
+}
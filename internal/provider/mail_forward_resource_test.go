@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRefreshMailForwardModel(t *testing.T) {
+	t.Parallel()
+
+	known := mailForwardResourceModel{
+		ID:      types.StringValue("sales@example.com"),
+		Targets: stringValues([]string{"Bob@example.org", "alice@example.org"}),
+	}
+
+	refreshed := refreshMailForwardModel(known, allinkl.MailForward{Targets: "alice@example.org, bob@example.org"})
+	if !reflect.DeepEqual(refreshed.Targets, known.Targets) {
+		t.Errorf("expected targets differing in case and order to be kept, got %v", refreshed.Targets)
+	}
+
+	refreshed = refreshMailForwardModel(known, allinkl.MailForward{Targets: "carol@example.org"})
+	if want := stringValues([]string{"carol@example.org"}); !reflect.DeepEqual(refreshed.Targets, want) {
+		t.Errorf("unexpected targets %v, want %v", refreshed.Targets, want)
+	}
+}
+
+func TestMailForwardCycle(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		graph map[string][]string
+		want  []string
+	}{
+		"no-loop": {
+			graph: map[string][]string{"a@example.com": {"b@example.com"}, "b@example.com": {"carol@example.org"}},
+		},
+		"two-forwards": {
+			graph: map[string][]string{"a@example.com": {"b@example.com"}, "b@example.com": {"A@Example.com"}},
+			want:  []string{"a@example.com", "b@example.com", "a@example.com"},
+		},
+		"to-itself": {
+			graph: map[string][]string{"a@example.com": {"carol@example.org", "a@example.com"}},
+			want:  []string{"a@example.com", "a@example.com"},
+		},
+		"long-loop": {
+			graph: map[string][]string{
+				"a@example.com": {"carol@example.org", "b@example.com"},
+				"b@example.com": {"c@example.com"},
+				"c@example.com": {"a@example.com"},
+			},
+			want: []string{"a@example.com", "b@example.com", "c@example.com", "a@example.com"},
+		},
+		"other-loop": {
+			graph: map[string][]string{
+				"a@example.com": {"b@example.com"},
+				"b@example.com": {"c@example.com"},
+				"c@example.com": {"b@example.com"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := mailForwardCycle(testCase.graph, "a@example.com"); !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("mailForwardCycle() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// newServer starts a mock KAS server holding the zone example.com, closed
+// when the test ends.
+func newServer(t *testing.T) *allinkltest.Server {
+	t.Helper()
+
+	server := allinkltest.NewServer("login", "password")
+	t.Cleanup(server.Close)
+	server.AddZone("example.com")
+	return server
+}
+
+// providerConfig returns a provider block using server with the extra
+// provider attributes in body, e.g. `debug_responses = true`.
+func providerConfig(server *allinkltest.Server, body string) string {
+	return fmt.Sprintf(`
+provider "allinkl" {
+  username      = "login"
+  password      = "password"
+  api_endpoint  = %q
+  auth_endpoint = %q
+%s
+}
+`, server.APIEndpoint(), server.AuthEndpoint(), body)
+}
+
+// checkRecords returns a check comparing the changeable records of zone,
+// formatted as "<type> <name> <data>", with want in any order.
+func checkRecords(server *allinkltest.Server, zone string, want ...string) func(*terraform.State) error {
+	return func(*terraform.State) error {
+		return compareRecords(server, zone, want...)
+	}
+}
+
+// changeRecordData changes the data of the record of zone with the given
+// type and name through the KAS API, like a change outside of Terraform.
+func changeRecordData(t *testing.T, server *allinkltest.Server, zone, recordType, name, data string) {
+	t.Helper()
+
+	for _, record := range server.Records(zone) {
+		if record.Type != recordType || record.Name != name {
+			continue
+		}
+		client := allinkl.NewClientWithEndpoints("login", "password", server.APIEndpoint(), server.AuthEndpoint())
+		if _, err := client.UpdateDNSSettings(context.Background(), allinkl.DNSRequest{
+			RecordId:   record.ID,
+			ZoneHost:   zone,
+			RecordType: recordType,
+			RecordName: name,
+			RecordData: data,
+			RecordAux:  record.Aux,
+		}); err != nil {
+			t.Fatalf("UpdateDNSSettings: %s", err)
+		}
+		return
+	}
+	t.Fatalf("zone %s holds no %s record %q", zone, recordType, name)
+}
+
+func compareRecords(server *allinkltest.Server, zone string, want ...string) error {
+	remaining := map[string]int{}
+	for _, record := range want {
+		remaining[record]++
+	}
+
+	var got []string
+	for _, record := range server.Records(zone) {
+		if !record.Changeable {
+			continue
+		}
+		formatted := fmt.Sprintf("%s %s %s", record.Type, record.Name, record.Data)
+		got = append(got, formatted)
+		remaining[formatted]--
+	}
+	for _, count := range remaining {
+		if count != 0 {
+			return fmt.Errorf("records of %s = %q, want %q", zone, got, want)
+		}
+	}
+	return nil
+}
+
+// lagDNS returns a PreConfig making the nameserver of server lag behind its
+// zones for delay, and a check failing unless the step waited that long,
+// i.e. until its changes propagated.
+func lagDNS(server *allinkltest.Server, delay time.Duration) (func(), func(*terraform.State) error) {
+	var propagated atomic.Bool
+	preConfig := func() {
+		propagated.Store(false)
+		server.SetDNSLag(true)
+		time.AfterFunc(delay, func() {
+			propagated.Store(true)
+			server.SetDNSLag(false)
+		})
+	}
+	check := func(*terraform.State) error {
+		if !propagated.Load() {
+			return fmt.Errorf("step finished before its changes propagated")
+		}
+		return nil
+	}
+	return preConfig, check
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// txtChunkSize is the maximum length in bytes of a single TXT
+// character-string.
+const txtChunkSize = 255
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &normalizeTXTFunction{}
+)
+
+// NewNormalizeTXTFunction is a helper function to simplify the provider implementation.
+func NewNormalizeTXTFunction() function.Function {
+	return &normalizeTXTFunction{}
+}
+
+// normalizeTXTFunction is the function implementation.
+type normalizeTXTFunction struct{}
+
+// Metadata returns the function name.
+func (f *normalizeTXTFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_txt"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *normalizeTXTFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Quote and chunk a string into TXT record data",
+		MarkdownDescription: "Escapes quotes, backslashes and control characters in the given string, splits it into character-strings " +
+			"of at most 255 bytes without breaking UTF-8 sequences, and returns the quoted chunks separated by spaces, " +
+			"ready to be used as `record_data` of a TXT record.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "Arbitrary TXT record content.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run returns the normalized TXT record data.
+func (f *normalizeTXTFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalizeTXT(value)))
+}
+
+// normalizeTXT converts an arbitrary string into quoted TXT character-strings.
+func normalizeTXT(value string) string {
+	chunks := splitTXT(value)
+
+	quoted := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		quoted = append(quoted, quoteCharacterString(chunk))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// splitTXT splits value into chunks of at most txtChunkSize bytes without
+// splitting multi-byte UTF-8 sequences.
+func splitTXT(value string) []string {
+	if value == "" {
+		return []string{""}
+	}
+
+	var chunks []string
+	for len(value) > txtChunkSize {
+		cut := txtChunkSize
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		chunks = append(chunks, value[:cut])
+		value = value[cut:]
+	}
+
+	return append(chunks, value)
+}
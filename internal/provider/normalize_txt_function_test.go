@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTXT(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("a", 300)
+
+	testCases := map[string]struct {
+		value string
+		want  string
+	}{
+		"empty":           {value: "", want: `""`},
+		"plain":           {value: "v=spf1 -all", want: `"v=spf1 -all"`},
+		"quoted":          {value: `"v=spf1 -all"`, want: `"\"v=spf1 -all\""`},
+		"chunked":         {value: `"part1" "part2"`, want: `"\"part1\" \"part2\""`},
+		"escaped-quote":   {value: `say \"hi\"`, want: `"say \\\"hi\\\""`},
+		"backslash":       {value: `C:\path`, want: `"C:\\path"`},
+		"exactly-255":     {value: long[:255], want: `"` + long[:255] + `"`},
+		"longer-than-255": {value: long, want: `"` + long[:255] + `" "` + long[255:] + `"`},
+		"escape-at-cut":   {value: long[:254] + `"x`, want: `"` + long[:254] + `\"" "x"`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := normalizeTXT(testCase.value); got != testCase.want {
+				t.Errorf("normalizeTXT(%q) = %q, want %q", testCase.value, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestSplitTXT(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("a", 600)
+	umlauts := strings.Repeat("a", 254) + "äöü"
+
+	testCases := map[string]struct {
+		value string
+		want  []string
+	}{
+		"empty":           {value: "", want: []string{""}},
+		"short":           {value: "v=spf1 -all", want: []string{"v=spf1 -all"}},
+		"exactly-255":     {value: long[:255], want: []string{long[:255]}},
+		"longer-than-255": {value: long, want: []string{long[:255], long[255:510], long[510:]}},
+		"multi-byte-cut":  {value: umlauts, want: []string{umlauts[:254], umlauts[254:]}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := splitTXT(testCase.value)
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("splitTXT(%q) = %q, want %q", testCase.value, got, testCase.want)
+			}
+			for _, chunk := range got {
+				if len(chunk) > txtChunkSize {
+					t.Errorf("chunk of %d bytes exceeds %d", len(chunk), txtChunkSize)
+				}
+			}
+		})
+	}
+}
@@ -4,37 +4,63 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"terraform-provider-allinkl/internal/allinkl"
 	"time"
 
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &dnsResource{}
-	_ resource.ResourceWithConfigure   = &dnsResource{}
-	_ resource.ResourceWithImportState = &dnsResource{}
+	_ resource.Resource                     = &dnsResource{}
+	_ resource.ResourceWithConfigure        = &dnsResource{}
+	_ resource.ResourceWithConfigValidators = &dnsResource{}
+	_ resource.ResourceWithImportState      = &dnsResource{}
+	_ resource.ResourceWithModifyPlan       = &dnsResource{}
+	_ resource.ResourceWithMoveState        = &dnsResource{}
+	_ resource.ResourceWithUpgradeState     = &dnsResource{}
+	_ resource.ResourceWithValidateConfig   = &dnsResource{}
 )
 
-// NewDNSResource is a helper function to simplify the provider implementation.
-func NewDNSResource() resource.Resource {
+// NewDNSRecordResource is a helper function to simplify the provider implementation.
+func NewDNSRecordResource() resource.Resource {
 	return &dnsResource{}
 }
 
+// NewDNSResource returns the deprecated allinkl_dns alias of
+// allinkl_dns_record.
+func NewDNSResource() resource.Resource {
+	return &dnsResource{deprecatedAlias: true}
+}
+
 // dnsResource is the resource implementation.
 type dnsResource struct {
-	client *allinkl.Client
+	client       *allinkl.Client
+	providerData *allinklProviderData
+
+	// deprecatedAlias is set for the allinkl_dns type, the former name of
+	// allinkl_dns_record.
+	deprecatedAlias bool
 }
 
 // Metadata returns the resource type name.
 func (r *dnsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_dns"
+	if r.deprecatedAlias {
+		resp.TypeName = req.ProviderTypeName + "_dns"
+		return
+	}
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
 }
 
 // dnsResourceModel maps the resource schema data.
@@ -46,11 +72,67 @@ type dnsResourceModel struct {
 	RecordName  types.String `tfsdk:"record_name"`
 	RecordData  types.String `tfsdk:"record_data"`
 	RecordAux   types.Int64  `tfsdk:"record_aux"`
+	Changeable  types.Bool   `tfsdk:"record_changeable"`
+	SRV         *dnsSRVModel `tfsdk:"srv"`
+	CAA         *dnsCAAModel `tfsdk:"caa"`
+	ManagedBy   types.String `tfsdk:"managed_by"`
+	FQDN        types.String `tfsdk:"fqdn"`
+
+	AllowAdopt    types.Bool  `tfsdk:"allow_adopt"`
+	CreateOnly    types.Bool  `tfsdk:"create_only"`
+	WaitForDelete types.Bool  `tfsdk:"wait_for_delete"`
+	CheckZoneSOA  types.Bool  `tfsdk:"check_zone_soa"`
+	ZoneSOASerial types.Int64 `tfsdk:"zone_soa_serial"`
+	ZoneTTL       types.Int64 `tfsdk:"zone_ttl"`
+
+	AllowDelegationChange types.Bool `tfsdk:"allow_delegation_change"`
+
+	WaitForPropagation *dnsWaitForPropagationModel `tfsdk:"wait_for_propagation"`
+	Timeouts           *timeoutsModel              `tfsdk:"timeouts"`
+	Retry              *retryModel                 `tfsdk:"retry"`
+}
+
+// structuredRecordData is implemented by the structured record data
+// attributes composing record_data.
+type structuredRecordData interface {
+	isKnown() bool
+	recordData() string
+	validate() error
+}
+
+// structuredData returns the structured record data attributes that are set,
+// keyed by attribute name. Each attribute is named after its record type.
+func (m dnsResourceModel) structuredData() map[string]structuredRecordData {
+	data := map[string]structuredRecordData{}
+	if m.SRV != nil {
+		data["srv"] = m.SRV
+	}
+	if m.CAA != nil {
+		data["caa"] = m.CAA
+	}
+	return data
+}
+
+// zoneRecords returns the record as a zone record, or no records if the
+// model is empty.
+func (m dnsResourceModel) zoneRecords() []dnsZoneRecordModel {
+	if m.RecordType.IsNull() {
+		return nil
+	}
+
+	return []dnsZoneRecordModel{{
+		Type: m.RecordType,
+		Name: m.RecordName,
+		Data: m.RecordData,
+		Aux:  m.RecordAux,
+	}}
 }
 
 // Schema defines the schema for the resource.
 func (r *dnsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             dnsResourceSchemaVersion,
+		MarkdownDescription: "Manages a single DNS record of a zone.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -60,24 +142,105 @@ func (r *dnsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"last_updated": schema.StringAttribute{
 				Computed: true,
+				MarkdownDescription: "When the provider last created or changed the record, in RFC 3339 format. KAS does not report " +
+					"modification times, so changes made outside of Terraform are not reflected. Null if the provider's " +
+					"`track_last_updated` is `false`.",
 			},
 			"zone_host": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfZoneChanged(),
+				},
 			},
 			"record_type": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringOneOf(supportedRecordTypes...),
+				},
 			},
 			"record_name": schema.StringAttribute{
-				Required: true,
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "The NAME of the record. Empty or `@` for the zone apex, which is the default.",
 			},
 			"record_data": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "The DATA of the resource record. Required unless one of the structured attributes `srv` or `caa` is set. " +
+					"TLSA records expect `<usage> <selector> <matching-type> <hex data>`, e.g. `3 1 1 0123...cdef`; " +
+					"the hex payload is compared case-insensitively.",
 			},
 			"record_aux": schema.Int64Attribute{
-				Required: true,
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The AUX of the record, e.g. the MX or SRV priority. Defaults to `0`, which is required for record types without a priority.",
+			},
+			"record_changeable": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether KAS allows the record to be changed. System records such as the zone's NS records are not changeable.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"managed_by": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Human-readable summary of how the record is identified in KAS, for outputs and runbooks.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The fully qualified name of the record, combining `record_name` and `zone_host`.",
+			},
+			"allow_adopt": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "On create, adopt an existing record with the same `record_type`, `record_name`, `record_data` and `record_aux` " +
+					"into the state instead of failing with a duplicate error. Useful when migrating manually managed zones.",
+			},
+			"create_only": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Create the record if it is missing, but never update or delete it afterwards. Configuration changes " +
+					"and destroys only affect the Terraform state, so the record can be handed over to another system after bootstrapping.",
 			},
+			"wait_for_delete": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "After deleting the record, wait until KAS no longer lists it before reporting the delete as done, " +
+					"so a record of the same name and type created right after it does not collide with it. Bounded by the delete timeout, or 5 minutes if none is set.",
+			},
+			"allow_delegation_change": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Allow creating, changing or deleting an NS record at the zone apex. Apex NS records delegate the zone, " +
+					"so such plans are rejected unless this is set, keeping a refactor from un-delegating a production zone.",
+			},
+			"check_zone_soa": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Query the All-Inkl nameservers for the zone's SOA after create and update, warning when a nameserver " +
+					"does not serve the zone authoritatively or serves a diverging serial.",
+			},
+			"zone_soa_serial": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The highest SOA serial of the zone served by the All-Inkl nameservers after the last apply. Only set if `check_zone_soa` is enabled.",
+			},
+			"zone_ttl": schema.Int64Attribute{
+				Computed: true,
+				MarkdownDescription: "The TTL in seconds the All-Inkl nameservers serve the zone's records with, as KAS does not support " +
+					"per-record TTLs. Taken from the SOA queried after the last apply; only set if `check_zone_soa` is enabled.",
+			},
+			"wait_for_propagation": waitForPropagationSchemaAttribute(),
+			"timeouts":             timeoutsSchemaAttribute(),
+			"retry":                retrySchemaAttribute(),
+			"srv":                  srvSchemaAttribute(),
+			"caa":                  caaSchemaAttribute(),
 		},
 	}
+
+	if r.deprecatedAlias {
+		resp.Schema.DeprecationMessage = "allinkl_dns is deprecated, use allinkl_dns_record instead. Existing resources can be " +
+			"migrated without recreating the records with a moved block from allinkl_dns to allinkl_dns_record (Terraform 1.8 or later)."
+	}
 }
 
 func (d *dnsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -87,22 +250,318 @@ func (d *dnsResource) Configure(_ context.Context, req resource.ConfigureRequest
 		return
 	}
 
-	client, ok := req.ProviderData.(*allinkl.Client)
-	if !ok {
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
+
+// ConfigValidators returns the resource-level validators.
+func (r *dnsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		recordAuxValidator{},
+	}
+}
+
+// ValidateConfig validates record_data against the format of the configured
+// record type.
+func (r *dnsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config dnsResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.RecordType.IsNull() || config.RecordType.IsUnknown() {
+		return
+	}
+	recordType := config.RecordType.ValueString()
+
+	if config.WaitForPropagation != nil && !propagationCheckRecordTypes[recordType] {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_for_propagation"),
+			"Unsupported Propagation Check",
+			"Waiting for propagation is not supported for "+recordType+" records.",
+		)
+	}
+
+	structured := config.structuredData()
+	if len(structured) > 1 {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *allinkl.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Conflicting Record Data",
+			"Only one of the srv and caa attributes can be set.",
+		)
+		return
+	}
+
+	for name, data := range structured {
+		if strings.ToUpper(name) != recordType {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unexpected Structured Record Data",
+				fmt.Sprintf("The %s attribute can only be set for %s records, got record_type %s.", name, strings.ToUpper(name), recordType),
+			)
+			return
+		}
+		if !config.RecordData.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Conflicting Record Data",
+				fmt.Sprintf("Set either record_data or %s, not both.", name),
+			)
+			return
+		}
+		if err := data.validate(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid "+recordType+" Record Data",
+				err.Error(),
+			)
+		}
+		return
+	}
+
+	if config.RecordData.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("record_data"),
+			"Missing Record Data",
+			"The record_data attribute is required for "+recordType+" records.",
 		)
+		return
+	}
 
+	if config.RecordData.IsUnknown() {
 		return
 	}
 
-	d.client = client
+	if err := validateRecordData(recordType, config.RecordData.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("record_data"),
+			"Invalid "+recordType+" Record Data",
+			err.Error(),
+		)
+	}
+}
+
+// ModifyPlan composes record_data from structured attributes, warns about
+// changes to infrastructure records and rejects changes to records KAS marks
+// as not changeable.
+func (r *dnsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(r.checkInfrastructureChange(ctx, req)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() {
+		var plan dnsResourceModel
+		diags := req.Plan.Get(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(r.planLastUpdated(ctx, req, resp, plan)...)
+
+		if !plan.RecordName.IsUnknown() && !plan.ZoneHost.IsUnknown() {
+			fqdn := recordFQDN(plan.RecordName.ValueString(), plan.ZoneHost.ValueString())
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("fqdn"), fqdn)...)
+		}
+
+		for _, data := range plan.structuredData() {
+			if data.isKnown() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("record_data"), data.recordData())...)
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(r.checkCNAMEConflict(ctx, req)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to check when the record is being created.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state dnsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Changeable.IsNull() || state.Changeable.IsUnknown() || state.Changeable.ValueBool() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		addNotDeletableError(&resp.Diagnostics, state)
+		return
+	}
+
+	var plan dnsResourceModel
+	diags = resp.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ZoneHost.Equal(state.ZoneHost) && plan.RecordType.Equal(state.RecordType) && plan.RecordName.Equal(state.RecordName) &&
+		recordDataValuesEqual(plan.RecordType.ValueString(), plan.RecordData, state.RecordData) && plan.RecordAux.Equal(state.RecordAux) {
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"AllInkl DNS Record Not Changeable",
+		fmt.Sprintf("The AllInkl dns record %s in zone %s is a system record (record_changeable = false) and cannot be modified. "+
+			"Revert the configuration to the current values.", state.ID.ValueString(), state.ZoneHost.ValueString()),
+	)
+}
+
+// checkCNAMEConflict rejects a plan creating a record, or moving it to
+// another name or type, that conflicts with a CNAME of the live zone. The
+// zone is only read in these cases, not for every plan.
+func (r *dnsResource) checkCNAMEConflict(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return diags
+	}
+
+	var state, plan dnsResourceModel
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.Get(ctx, &state)...)
+	}
+	diags.Append(req.Plan.Get(ctx, &plan)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if plan.ZoneHost.IsUnknown() || plan.RecordType.IsUnknown() || plan.RecordName.IsUnknown() {
+		return diags
+	}
+	if !req.State.Raw.IsNull() && plan.ZoneHost.Equal(state.ZoneHost) && plan.RecordType.Equal(state.RecordType) && plan.RecordName.Equal(state.RecordName) {
+		return diags
+	}
+
+	records, err := r.client.GetDNSSettings(ctx, toASCIIHostname(plan.ZoneHost.ValueString()), "")
+	if allinkl.IsNotFound(err) {
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Reading AllInkl DNS",
+			"Could not read AllInkl dns zone "+plan.ZoneHost.ValueString()+": "+r.providerData.kasErrorMessage(err),
+		)
+		return diags
+	}
+
+	ownID := ""
+	if plan.ZoneHost.Equal(state.ZoneHost) {
+		ownID = state.ID.ValueString()
+	}
+	diags.Append(cnameConflictError(records, plan.ZoneHost.ValueString(), plan.RecordType.ValueString(), toKASRecordName(plan.RecordName.ValueString()), plan.RecordData, ownID)...)
+	return diags
+}
+
+// checkInfrastructureChange warns if the plan creates, changes or deletes an
+// NS record, rejects deleting one if protect_system_records is enabled and
+// rejects any change to an apex NS record unless allow_delegation_change is
+// set.
+func (r *dnsResource) checkInfrastructureChange(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var state, plan dnsResourceModel
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.Get(ctx, &state)...)
+	}
+	if !req.Plan.Raw.IsNull() {
+		diags.Append(req.Plan.Get(ctx, &plan)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	zoneHost := plan.ZoneHost.ValueString()
+	if req.Plan.Raw.IsNull() {
+		zoneHost = state.ZoneHost.ValueString()
+	}
+
+	diags.Append(infrastructureRecordWarning(zoneHost, state.zoneRecords(), plan.zoneRecords())...)
+
+	// System records are guarded by record_changeable already.
+	if state.Changeable.Equal(types.BoolValue(false)) {
+		return diags
+	}
+
+	// Moving the record to another zone deletes it from its current zone.
+	planned := plan.zoneRecords()
+	if !req.State.Raw.IsNull() && !plan.ZoneHost.IsUnknown() && !hostnameEqual(plan.ZoneHost.ValueString(), state.ZoneHost.ValueString()) {
+		planned = nil
+	}
+	diags.Append(r.providerData.protectInfrastructureRecords(state.ZoneHost.ValueString(), state.zoneRecords(), planned)...)
+
+	// A destroy plan carries no configuration, so the flag is taken from
+	// the state.
+	allowDelegationChange := plan.AllowDelegationChange.ValueBool()
+	if req.Plan.Raw.IsNull() {
+		allowDelegationChange = state.AllowDelegationChange.ValueBool()
+	}
+	if !allowDelegationChange {
+		diags.Append(delegationChangeError(zoneHost, state.zoneRecords(), planned)...)
+	}
+	return diags
+}
+
+// planLastUpdated plans last_updated to keep its value unless the record
+// itself changes, so unrelated changes such as timeouts do not show up as a
+// new last_updated.
+func (r *dnsResource) planLastUpdated(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan dnsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !r.providerData.trackLastUpdated() {
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("last_updated"), types.StringNull())...)
+		return diags
+	}
+
+	if req.State.Raw.IsNull() {
+		return diags
+	}
+
+	var state dnsResourceModel
+	diags.Append(req.State.Get(ctx, &state)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	unchanged := plan.ZoneHost.Equal(state.ZoneHost) && plan.RecordType.Equal(state.RecordType) && plan.RecordName.Equal(state.RecordName) &&
+		recordDataValuesEqual(plan.RecordType.ValueString(), plan.RecordData, state.RecordData) && plan.RecordAux.Equal(state.RecordAux)
+	if unchanged || state.CreateOnly.ValueBool() {
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("last_updated"), state.LastUpdated)...)
+	}
+	return diags
+}
+
+// addNotDeletableError reports that a system record cannot be deleted.
+func addNotDeletableError(diags *diag.Diagnostics, state dnsResourceModel) {
+	diags.AddError(
+		"AllInkl DNS Record Not Changeable",
+		fmt.Sprintf("The AllInkl dns record %s in zone %s is a system record (record_changeable = false) and cannot be deleted. "+
+			"Remove it from the Terraform state with `terraform state rm` instead.", state.ID.ValueString(), state.ZoneHost.ValueString()),
+	)
 }
 
 // Create creates the resource and sets the initial Terraform state.
 // Create a new resource.
 func (r *dnsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan dnsResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -111,26 +570,104 @@ func (r *dnsResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.create())
+	defer cancel()
+	ctx = withRetry(ctx, plan.Retry)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve values from state
 	var allinklItem = allinkl.DNSRequest{
-		ZoneHost:   plan.ZoneHost.ValueString(),
+		ZoneHost:   toASCIIHostname(plan.ZoneHost.ValueString()),
 		RecordType: plan.RecordType.ValueString(),
-		RecordName: plan.RecordName.ValueString(),
+		RecordName: toKASRecordName(plan.RecordName.ValueString()),
 		RecordData: plan.RecordData.ValueString(),
 		RecordAux:  int(plan.RecordAux.ValueInt64()),
 	}
 
-	id, err := r.client.AddDNSSettings(ctx, allinklItem)
-	if err != nil {
+	existing, records, diags := findDNSRecord(ctx, r.providerData, allinklItem)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if existing == nil {
+		// Records of the same configuration created earlier in this apply
+		// were not there yet when the plan was checked.
+		resp.Diagnostics.Append(cnameConflictError(records, plan.ZoneHost.ValueString(), allinklItem.RecordType, allinklItem.RecordName, plan.RecordData, "")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	adopt := r.providerData.adopt(plan.AllowAdopt)
+	if existing != nil && !adopt {
+		importID := formatDNSImportID(plan.ZoneHost.ValueString(), fmt.Sprint(existing.ID))
 		resp.Diagnostics.AddError(
-			"Error Creating AllInkl DNS",
-			"Could not create dns, unexpected error: "+err.Error(),
+			"AllInkl DNS Record Already Exists",
+			fmt.Sprintf("Zone %s already holds an identical %s record with ID %s. Import it into the state instead of creating it:\n\n"+
+				"  terraform import <resource address> %q\n\n"+
+				"or use an import block with id = %q, or set allow_adopt = true on the resource or auto_adopt = true on the provider.",
+				plan.ZoneHost.ValueString(), allinklItem.RecordType, fmt.Sprint(existing.ID), importID, importID),
 		)
 		return
 	}
 
+	var id string
+	changeable := true
+	if existing == nil {
+		var err error
+		id, err = r.client.AddDNSSettings(ctx, allinklItem)
+		if allinkl.IsAlreadyExists(err) && adopt {
+			// The record was created concurrently, e.g. by a retried
+			// request whose first attempt succeeded.
+			existing, _, diags = findDNSRecord(ctx, r.providerData, allinklItem)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			if existing != nil {
+				err = nil
+			}
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating AllInkl DNS",
+				"Could not create dns, unexpected error: "+r.providerData.kasErrorMessage(err),
+			)
+			return
+		}
+	}
+	if existing != nil {
+		id = fmt.Sprint(existing.ID)
+		changeable = existing.Changeable == "Y"
+		tflog.Info(ctx, "Adopting existing AllInkl DNS record", map[string]any{"zone_host": allinklItem.ZoneHost, "record_id": id})
+		if plan.AllowAdopt.IsNull() {
+			resp.Diagnostics.AddWarning(
+				"AllInkl DNS Record Adopted",
+				fmt.Sprintf("Zone %s already held an identical %s record with ID %s, which was adopted into the state instead of "+
+					"creating a new record because auto_adopt is enabled.", plan.ZoneHost.ValueString(), allinklItem.RecordType, id),
+			)
+		}
+	} else {
+		logDNSChange(ctx, dnsChange{
+			Action:     dnsChangeCreate,
+			RecordID:   id,
+			ZoneHost:   allinklItem.ZoneHost,
+			RecordType: allinklItem.RecordType,
+			RecordName: allinklItem.RecordName,
+			NewData:    allinklItem.RecordData,
+		})
+	}
+
 	plan.ID = types.StringValue(id)
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.Changeable = types.BoolValue(changeable)
+	plan.ManagedBy = managedByValue(plan.ZoneHost.ValueString(), id, changeable)
+	plan.FQDN = types.StringValue(recordFQDN(plan.RecordName.ValueString(), plan.ZoneHost.ValueString()))
+	plan.ZoneSOASerial = types.Int64Null()
+	plan.ZoneTTL = types.Int64Null()
+	plan.LastUpdated = r.providerData.lastUpdatedValue()
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -138,11 +675,30 @@ func (r *dnsResource) Create(ctx context.Context, req resource.CreateRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setDNSPrivateState(ctx, resp.Private, dnsPrivateState{
+		ETag:       recordETag(allinklItem.ZoneHost, allinklItem.RecordType, allinklItem.RecordName, allinklItem.RecordData, allinklItem.RecordAux),
+		ReadAt:     time.Now(),
+		Changeable: &changeable,
+	})...)
+	if plan.WaitForPropagation != nil {
+		resp.Diagnostics.Append(plan.WaitForPropagation.wait(ctx, plan.RecordType.ValueString(), plan.FQDN.ValueString(), plan.RecordData.ValueString())...)
+	}
+	if plan.CheckZoneSOA.ValueBool() {
+		serial, ttl, diags := checkZoneSOA(ctx, defaultNameservers, plan.ZoneHost.ValueString())
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_soa_serial"), serial)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_ttl"), ttl)...)
+	}
 }
 
 // Read refreshes the Terraform state with the latest data.
 // Read resource information.
 func (r *dnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
 	// Get current state
 	var state dnsResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -151,40 +707,63 @@ func (r *dnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.read())
+	defer cancel()
+	ctx = withRetry(ctx, state.Retry)
+
+	private, diags := getDNSPrivateState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if private.isFresh(r.providerData.RefreshMaxAge) {
+		tflog.Debug(ctx, "Skipping AllInkl dns refresh, record was read recently", map[string]any{
+			"record_id": state.ID.ValueString(),
+			"read_at":   private.ReadAt.Format(time.RFC3339),
+		})
+		return
+	}
+
 	// Get refreshed dns value from AllInkl
-	dns, err := r.client.GetDNSSettings(ctx, state.ZoneHost.ValueString(), state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading AllInkl DNS",
-			"Could not read AllInkl dns ID "+state.ID.ValueString()+": "+err.Error(),
-		)
+	record, diags := r.readRecord(ctx, state.ZoneHost.ValueString(), state.ID.ValueString(), state.zoneRecords())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var dnsCount int = len(dns)
-	if dnsCount == 0 {
-		resp.Diagnostics.AddError(
-			"Error Reading AllInkl DNS",
-			"Could not read AllInkl dns ID "+state.ID.ValueString()+": no records found, expected 1",
-		)
+	if record == nil {
+		// The record was deleted outside of Terraform; drop it from state
+		// so the next plan recreates it.
+		tflog.Warn(ctx, "AllInkl dns record not found, removing from state", map[string]any{
+			"zone_host": state.ZoneHost.ValueString(),
+			"record_id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	if dnsCount > 1 {
-		resp.Diagnostics.AddError(
-			"Error Reading AllInkl DNS",
-			fmt.Sprintf("Could not read AllInkl dns ID %s: found %d records, expected 1", state.ID.ValueString(), dnsCount),
-		)
+	if state.CreateOnly.ValueBool() && !state.RecordType.IsNull() {
+		// The record exists, which is all a create_only record tracks;
+		// changes made by other systems are not reported as drift.
 		return
 	}
 
-	state = dnsResourceModel{
-		ID:         state.ID,
-		ZoneHost:   types.StringValue(dns[0].ZoneHost),
-		RecordType: types.StringValue(dns[0].RecordType),
-		RecordName: types.StringValue(dns[0].RecordName),
-		RecordData: types.StringValue(dns[0].RecordData),
-		RecordAux:  types.Int64Value(int64(dns[0].RecordAux)),
+	if !state.RecordType.IsNull() && !strings.EqualFold(state.RecordType.ValueString(), record.RecordType) {
+		resp.Diagnostics.AddWarning(
+			"AllInkl DNS Record Changed Type",
+			fmt.Sprintf("The AllInkl dns record %s in zone %s changed its type outside of Terraform:\n\n%s\n"+
+				"The next apply changes it back to the configuration. Re-import the record if the change is intended, "+
+				"or delete it in KAS and remove it from the state to have Terraform create a new one.",
+				state.ID.ValueString(), state.ZoneHost.ValueString(), recordComparison(state.zoneRecords(), []allinkl.ReturnInfo{*record})),
+		)
+	}
+
+	state = refreshDNSModel(state, *record)
+	if state.LastUpdated.IsNull() {
+		// Imported records have no last_updated yet; populate it so
+		// generated configuration and plans see a complete state.
+		state.LastUpdated = r.providerData.lastUpdatedValue()
 	}
 
 	// Set refreshed state
@@ -193,10 +772,20 @@ func (r *dnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setDNSPrivateState(ctx, resp.Private, dnsPrivateState{
+		ETag:       remoteRecordETag(*record),
+		ReadAt:     time.Now(),
+		Changeable: remoteChangeable(*record),
+	})...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *dnsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan dnsResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -205,59 +794,79 @@ func (r *dnsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.update())
+	defer cancel()
+	ctx = withRetry(ctx, plan.Retry)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.CreateOnly.ValueBool() {
+		r.updateStateOnly(ctx, req, resp, plan)
+		return
+	}
+
+	r.warnIfModifiedExternally(ctx, req.Private, plan.ZoneHost.ValueString(), plan.ID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	var allinklItem = allinkl.DNSRequest{
 		RecordId:   plan.ID.ValueString(),
-		ZoneHost:   plan.ZoneHost.ValueString(),
+		ZoneHost:   toASCIIHostname(plan.ZoneHost.ValueString()),
 		RecordType: plan.RecordType.ValueString(),
-		RecordName: plan.RecordName.ValueString(),
+		RecordName: toKASRecordName(plan.RecordName.ValueString()),
 		RecordData: plan.RecordData.ValueString(),
 		RecordAux:  int(plan.RecordAux.ValueInt64()),
 	}
 
+	var state dnsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	_, err := r.client.UpdateDNSSettings(ctx, allinklItem)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating AllInkl DNS",
-			"Could not update dns, unexpected error: "+err.Error(),
+			"Could not update dns, unexpected error: "+r.providerData.kasErrorMessage(err),
 		)
 		return
 	}
+	logDNSChange(ctx, dnsChange{
+		Action:     dnsChangeUpdate,
+		RecordID:   allinklItem.RecordId,
+		ZoneHost:   allinklItem.ZoneHost,
+		RecordType: allinklItem.RecordType,
+		RecordName: allinklItem.RecordName,
+		OldData:    state.RecordData.ValueString(),
+		NewData:    allinklItem.RecordData,
+	})
 
 	// Set state to fully populated data
-	dns, err := r.client.GetDNSSettings(ctx, plan.ZoneHost.ValueString(), plan.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading AllInkl DNS",
-			"Could not read AllInkl dns ID "+plan.ID.ValueString()+": "+err.Error(),
-		)
-		return
-	}
-	var dnsCount int = len(dns)
-	if dnsCount == 0 {
-		resp.Diagnostics.AddError(
-			"Error Reading AllInkl DNS",
-			"Could not read AllInkl dns ID "+plan.ID.ValueString()+": no records found, expected 1",
-		)
+	record, diags := r.readRecord(ctx, plan.ZoneHost.ValueString(), plan.ID.ValueString(), nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if dnsCount > 1 {
+	if record == nil {
 		resp.Diagnostics.AddError(
 			"Error Reading AllInkl DNS",
-			fmt.Sprintf("Could not read AllInkl dns ID %s: found %d records, expected 1", plan.ID.ValueString(), dnsCount),
+			"Could not read AllInkl dns ID "+plan.ID.ValueString()+": no records found, expected 1",
 		)
 		return
 	}
 
-	plan = dnsResourceModel{
-		ID:          plan.ID,
-		LastUpdated: types.StringValue(time.Now().Format(time.RFC850)),
-		ZoneHost:    types.StringValue(dns[0].ZoneHost),
-		RecordType:  types.StringValue(dns[0].RecordType),
-		RecordName:  types.StringValue(dns[0].RecordName),
-		RecordData:  types.StringValue(dns[0].RecordData),
-		RecordAux:   types.Int64Value(int64(dns[0].RecordAux)),
+	plan = refreshDNSModel(plan, *record)
+	plan.ZoneSOASerial = types.Int64Null()
+	plan.ZoneTTL = types.Int64Null()
+	if plan.LastUpdated.IsUnknown() {
+		plan.LastUpdated = r.providerData.lastUpdatedValue()
 	}
 
 	diags = resp.State.Set(ctx, plan)
@@ -265,10 +874,30 @@ func (r *dnsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setDNSPrivateState(ctx, resp.Private, dnsPrivateState{
+		ETag:       remoteRecordETag(*record),
+		ReadAt:     time.Now(),
+		Changeable: remoteChangeable(*record),
+	})...)
+
+	if plan.WaitForPropagation != nil {
+		resp.Diagnostics.Append(plan.WaitForPropagation.wait(ctx, plan.RecordType.ValueString(), plan.FQDN.ValueString(), plan.RecordData.ValueString())...)
+	}
+	if plan.CheckZoneSOA.ValueBool() {
+		serial, ttl, diags := checkZoneSOA(ctx, defaultNameservers, plan.ZoneHost.ValueString())
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_soa_serial"), serial)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_ttl"), ttl)...)
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *dnsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
 	// Retrieve values from state
 	var state dnsResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -277,42 +906,322 @@ func (r *dnsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	deleted, err := r.client.DeleteDNSSettings(ctx, state.ID.ValueString())
-	if !deleted {
+	ctx, cancel := withTimeout(ctx, state.Timeouts.delete())
+	defer cancel()
+	ctx = withRetry(ctx, state.Retry)
+
+	resp.Diagnostics.Append(r.providerData.acquireLease(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.CreateOnly.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"AllInkl DNS Record Not Deleted",
+			"The AllInkl dns record "+state.ID.ValueString()+" has create_only set and was only removed from the Terraform state.",
+		)
+		return
+	}
+
+	private, diags := getDNSPrivateState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if private.Changeable != nil && !*private.Changeable {
+		// Fail before calling KAS, which would reject the delete.
+		addNotDeletableError(&resp.Diagnostics, state)
+		return
+	}
+
+	r.warnIfModifiedExternally(ctx, req.Private, state.ZoneHost.ValueString(), state.ID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.DeleteDNSSettings(ctx, state.ID.ValueString())
+	if allinkl.IsNotFound(err) {
+		// Already gone, which is the desired outcome.
+		tflog.Warn(ctx, "AllInkl dns record already deleted", map[string]any{
+			"zone_host": state.ZoneHost.ValueString(),
+			"record_id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting AllInkl DNS",
-			"Could not delete dns, unexpected error: "+err.Error(),
+			"Could not delete dns ID "+state.ID.ValueString()+", unexpected error: "+r.providerData.kasErrorMessage(err),
 		)
 		return
 	}
-}
 
-func (r *dnsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if path.Root("id").Equal(path.Empty()) {
+	if !result.ReturnInfo {
 		resp.Diagnostics.AddError(
-			"Resource Import Passthrough Missing Attribute Path",
-			"This is always an error in the provider. Please report the following to the provider developer:\n\n"+
-				"Resource ImportState method call to ImportStatePassthroughID path must be set to a valid attribute path that can accept a string value.",
+			"Error Deleting AllInkl DNS",
+			"Could not delete dns ID "+state.ID.ValueString()+": KAS reported failure: "+r.providerData.kasReturnString(result.ReturnString),
 		)
+		return
 	}
+	logDNSChange(ctx, dnsChange{
+		Action:     dnsChangeDelete,
+		RecordID:   state.ID.ValueString(),
+		ZoneHost:   state.ZoneHost.ValueString(),
+		RecordType: state.RecordType.ValueString(),
+		RecordName: toKASRecordName(state.RecordName.ValueString()),
+		OldData:    state.RecordData.ValueString(),
+	})
 
-	// split into zone_host and record_id by `/`
-	var zoneHost, recordID string
-	if req.ID != "" {
-		zoneHost, recordID = req.ID, ""
-		if i := strings.Index(req.ID, "/"); i != -1 {
-			zoneHost, recordID = req.ID[:i], req.ID[i+1:]
+	if state.WaitForDelete.ValueBool() {
+		resp.Diagnostics.Append(waitForDNSRecordDeleted(ctx, r.providerData, state.ZoneHost.ValueString(), state.ID.ValueString())...)
+	}
+
+	if result.ReturnString != "" && result.ReturnString != "TRUE" {
+		resp.Diagnostics.AddWarning(
+			"AllInkl DNS Delete Returned A Message",
+			"KAS deleted dns ID "+state.ID.ValueString()+" and returned: "+r.providerData.kasReturnString(result.ReturnString),
+		)
+	}
+}
+
+// readRecord fetches a single record from KAS. It returns a nil record without
+// diagnostics if the record does not exist.
+func (r *dnsResource) readRecord(ctx context.Context, zoneHost, recordID string, known []dnsZoneRecordModel) (*allinkl.ReturnInfo, diag.Diagnostics) {
+	return readDNSRecord(ctx, r.providerData, zoneHost, recordID, known)
+}
+
+// readDNSRecord fetches a single record from KAS. It returns a nil record
+// without diagnostics if the record does not exist. If KAS returns several
+// records, the error compares them with the known values of the record.
+func readDNSRecord(ctx context.Context, data *allinklProviderData, zoneHost, recordID string, known []dnsZoneRecordModel) (*allinkl.ReturnInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	dns, err := data.Client.GetDNSSettings(ctx, toASCIIHostname(zoneHost), recordID)
+	if allinkl.IsNotFound(err) {
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Reading AllInkl DNS",
+			"Could not read AllInkl dns ID "+recordID+": "+data.kasErrorMessage(err),
+		)
+		return nil, diags
+	}
+
+	switch len(dns) {
+	case 0:
+		return nil, diags
+	case 1:
+		return &dns[0], diags
+	default:
+		diags.AddError(
+			"Error Reading AllInkl DNS",
+			fmt.Sprintf("Could not read AllInkl dns ID %s: found %d records, expected 1.\n\n%s\n"+
+				"Re-import the record matching the configuration by its own ID, or remove the unexpected records in KAS.",
+				recordID, len(dns), recordComparison(known, dns)),
+		)
+		return nil, diags
+	}
+}
+
+// updateStateOnly stores the plan of a create_only record without changing
+// the record in KAS.
+func (r *dnsResource) updateStateOnly(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse, plan dnsResourceModel) {
+	var state dnsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.LastUpdated = state.LastUpdated
+	plan.ZoneSOASerial = state.ZoneSOASerial
+	plan.ZoneTTL = state.ZoneTTL
+
+	resp.Diagnostics.AddWarning(
+		"AllInkl DNS Record Not Updated",
+		"The AllInkl dns record "+plan.ID.ValueString()+" has create_only set; the configuration change was only stored in the Terraform state.",
+	)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// findDNSRecord returns the record of the zone of record matching its type,
+// name, data and aux, or nil if the zone holds no such record.
+// The records of the zone are returned as well, empty if the zone does not
+// exist.
+func findDNSRecord(ctx context.Context, data *allinklProviderData, record allinkl.DNSRequest) (*allinkl.ReturnInfo, []allinkl.ReturnInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	records, err := data.Client.GetDNSSettings(ctx, record.ZoneHost, "")
+	if allinkl.IsNotFound(err) {
+		return nil, nil, diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Reading AllInkl DNS",
+			"Could not read AllInkl dns zone "+record.ZoneHost+": "+data.kasErrorMessage(err),
+		)
+		return nil, nil, diags
+	}
+
+	for i, candidate := range records {
+		if strings.EqualFold(candidate.RecordType, record.RecordType) &&
+			hostnameEqual(candidate.RecordName, record.RecordName) &&
+			recordDataEqual(record.RecordType, candidate.RecordData, record.RecordData) &&
+			candidate.RecordAux == record.RecordAux {
+			return &records[i], records, diags
 		}
 	}
+	return nil, records, diags
+}
+
+// refreshDNSModel returns known updated with the remote values of record,
+// keeping known values that only differ in formatting.
+func refreshDNSModel(known dnsResourceModel, record allinkl.ReturnInfo) dnsResourceModel {
+	return dnsResourceModel{
+		ID:          known.ID,
+		LastUpdated: known.LastUpdated,
+		ZoneHost:    zoneHostValue(known.ZoneHost, record.ZoneHost),
+		RecordType:  types.StringValue(record.RecordType),
+		RecordName:  recordNameValue(known.RecordName, record.RecordName),
+		RecordData:  recordDataValue(record.RecordType, known.RecordData, record.RecordData),
+		RecordAux:   types.Int64Value(int64(record.RecordAux)),
+		Changeable:  types.BoolValue(record.Changeable == "Y"),
+		SRV:         srvValue(known.SRV, record.RecordData),
+		CAA:         caaValue(known.CAA, record.RecordData),
+		ManagedBy:   managedByValue(record.ZoneHost, known.ID.ValueString(), record.Changeable == "Y"),
+		FQDN:        types.StringValue(recordFQDN(record.RecordName, record.ZoneHost)),
+
+		AllowAdopt:    known.AllowAdopt,
+		CreateOnly:    known.CreateOnly,
+		WaitForDelete: known.WaitForDelete,
+		CheckZoneSOA:  known.CheckZoneSOA,
+		ZoneSOASerial: known.ZoneSOASerial,
+		ZoneTTL:       known.ZoneTTL,
+
+		AllowDelegationChange: known.AllowDelegationChange,
+
+		WaitForPropagation: known.WaitForPropagation,
+		Timeouts:           known.Timeouts,
+		Retry:              known.Retry,
+	}
+}
+
+// managedByValue summarizes how a record is identified in KAS.
+func managedByValue(zoneHost, recordID string, changeable bool) types.String {
+	mode := "changeable"
+	if !changeable {
+		mode = "system record, not changeable"
+	}
+	return types.StringValue(fmt.Sprintf("KAS zone %s, record_id %s (%s)", normalizeHostname(zoneHost), recordID, mode))
+}
+
+// warnIfModifiedExternally compares the remote record with the ETag recorded
+// in private state and warns when the record was changed outside of
+// Terraform since it was last read.
+func (r *dnsResource) warnIfModifiedExternally(ctx context.Context, private privateStateGetter, zoneHost, recordID string, diags *diag.Diagnostics) {
+	data, privateDiags := getDNSPrivateState(ctx, private)
+	diags.Append(privateDiags...)
+	if diags.HasError() || data.ETag == "" {
+		return
+	}
+
+	// Lookup failures are reported by the operation that follows.
+	record, readDiags := r.readRecord(ctx, zoneHost, recordID, nil)
+	if readDiags.HasError() || record == nil {
+		return
+	}
+
+	if remoteRecordETag(*record) != data.ETag {
+		diags.AddWarning(
+			"AllInkl DNS Record Modified Externally",
+			fmt.Sprintf("The AllInkl dns record %s in zone %s was changed outside of Terraform since it was last read. "+
+				"The planned change is applied over the current remote values (type %s, name %q, data %q, aux %d).",
+				recordID, zoneHost, record.RecordType, record.RecordName, record.RecordData, record.RecordAux),
+		)
+	}
+}
+
+// ImportState imports a record by its `zone_host/record_id` or
+// `zone_host:record_id` ID, or by a
+// `zone_host/record_type/record_name[/record_data]` lookup.
+func (r *dnsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if isDNSLookupImportID(req.ID) {
+		r.importByLookup(ctx, req, resp)
+		return
+	}
 
-	if recordID == "" || zoneHost == "" {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	zoneHost, recordID, err := parseDNSImportID(req.ID)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			"Expected import ID in the format `zone_host/record_id`, got: "+req.ID,
+			err.Error(),
 		)
 		return
 	}
 
+	resp.Diagnostics.Append(verifyImportedDNSRecord(ctx, r.providerData, req.ID, zoneHost, recordID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_host"), zoneHost)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), recordID)...)
 }
+
+// importByLookup imports the record matching a
+// `zone_host/record_type/record_name[/record_data]` import ID.
+func (r *dnsResource) importByLookup(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, recorder := r.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+	defer r.providerData.appendFloodAdvice(&resp.Diagnostics)
+
+	lookup, err := parseDNSLookupImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			err.Error(),
+		)
+		return
+	}
+
+	records, err := r.client.GetDNSSettings(ctx, toASCIIHostname(lookup.ZoneHost), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading AllInkl DNS",
+			"Could not read AllInkl dns zone "+lookup.ZoneHost+": "+r.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	var ids []string
+	for _, record := range records {
+		if strings.EqualFold(record.RecordType, lookup.RecordType) &&
+			hostnameEqual(record.RecordName, toKASRecordName(lookup.RecordName)) &&
+			(lookup.RecordData == nil || recordDataEqual(lookup.RecordType, record.RecordData, *lookup.RecordData)) {
+			ids = append(ids, fmt.Sprint(record.ID))
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"AllInkl DNS Record Not Found",
+			fmt.Sprintf("Zone %s holds no %s record named %q matching import ID %q.", lookup.ZoneHost, lookup.RecordType, lookup.RecordName, req.ID),
+		)
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError(
+			"Ambiguous AllInkl DNS Import ID",
+			fmt.Sprintf("Zone %s holds %d %s records named %q (IDs %s). Append the record_data to the import ID or import by `zone_host/record_id`.",
+				lookup.ZoneHost, len(ids), lookup.RecordType, lookup.RecordName, strings.Join(ids, ", ")),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_host"), lookup.ZoneHost)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+}
@@ -0,0 +1,327 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/ViMaSter/terraform-provider-allinkl/internal/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestDNSRecordWaitForDelete(t *testing.T) {
+	server := newServer(t)
+	defer provider.SetDeleteWaitTimeout(time.Second)()
+
+	config := server.ProviderConfig() + `
+resource "allinkl_dns_record" "www" {
+  zone_host       = "example.com"
+  record_type     = "A"
+  record_name     = "www"
+  record_data     = "192.0.2.1"
+  wait_for_delete = true
+}
+`
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  checkRecords(server, "example.com", "A www 192.0.2.1"),
+			},
+			{
+				// Without a delete timeout, waiting for a record KAS keeps
+				// listing gives up after the default bound.
+				PreConfig:   func() { server.KeepDeleted(true) },
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`AllInkl DNS Record Still Present`),
+			},
+			{
+				PreConfig: func() { server.KeepDeleted(false) },
+				Config:    config,
+				Check:     checkRecords(server, "example.com", "A www 192.0.2.1"),
+			},
+		},
+		CheckDestroy: checkRecords(server, "example.com"),
+	})
+}
+
+func TestDNSRecordWaitsForDelete(t *testing.T) {
+	server := newServer(t)
+	defer provider.SetDeletePollInterval(100 * time.Millisecond)()
+
+	var purged atomic.Bool
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_dns_record" "www" {
+  zone_host       = "example.com"
+  record_type     = "A"
+  record_name     = "www"
+  record_data     = "192.0.2.1"
+  wait_for_delete = true
+}
+`,
+			},
+			{
+				// KAS lists the deleted record for another second.
+				PreConfig: func() {
+					server.KeepDeleted(true)
+					time.AfterFunc(time.Second, func() {
+						purged.Store(true)
+						server.KeepDeleted(false)
+					})
+				},
+				Config: server.ProviderConfig(),
+				Check: func(*terraform.State) error {
+					if !purged.Load() {
+						return fmt.Errorf("delete finished while KAS still listed the record")
+					}
+					return compareRecords(server, "example.com")
+				},
+			},
+		},
+	})
+}
+
+func TestDNSRecordTimeoutCoversLease(t *testing.T) {
+	server := newServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server, `  lease = { zone = "example.com" }`) + `
+resource "allinkl_dns_record" "www" {
+  zone_host   = "example.com"
+  record_type = "A"
+  record_name = "www"
+  record_data = "192.0.2.1"
+  timeouts    = { create = "1ns" }
+}
+`,
+				ExpectError: regexp.MustCompile(`deadline\s+exceeded`),
+			},
+		},
+	})
+
+	// The create timed out before it could take the lease.
+	if records := leaseRecordData(server); len(records) != 0 {
+		t.Errorf("lease records %q, want none", records)
+	}
+}
+
+func TestDNSRecordWarnsAboutExternalModification(t *testing.T) {
+	server := newServer(t)
+	p := newProtocolProvider(t, server, nil)
+	const summary = "AllInkl DNS Record Modified Externally"
+
+	config := map[string]any{
+		"zone_host":   "example.com",
+		"record_type": "A",
+		"record_name": "www",
+		"record_data": "192.0.2.1",
+	}
+	state, diags := p.apply("allinkl_dns_record", nil, config)
+	if hasErrors(diags) {
+		t.Fatalf("create: %s", formatDiagnostics(diags))
+	}
+
+	// Another tool changes the record after the last refresh.
+	changeRecordData(t, server, "example.com", "A", "www", "192.0.2.9")
+
+	config["record_data"] = "192.0.2.2"
+	state, diags = p.apply("allinkl_dns_record", state, config)
+	if hasErrors(diags) || !hasDiagnostic(diags, tfprotov6.DiagnosticSeverityWarning, summary) {
+		t.Fatalf("update over an external change: want a %q warning, got %s", summary, formatDiagnostics(diags))
+	}
+	if err := compareRecords(server, "example.com", "A www 192.0.2.2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The update recorded what it wrote, so the next change does not warn.
+	config["record_data"] = "192.0.2.3"
+	_, diags = p.apply("allinkl_dns_record", state, config)
+	if hasErrors(diags) || hasDiagnostic(diags, tfprotov6.DiagnosticSeverityWarning, summary) {
+		t.Fatalf("update: want no %q warning, got %s", summary, formatDiagnostics(diags))
+	}
+}
+
+func TestDNSRecordWaitForPropagation(t *testing.T) {
+	server := newServer(t)
+	defer provider.SetPropagationPollInterval(100 * time.Millisecond)()
+	lag, waited := lagDNS(server, time.Second)
+
+	config := func(data, timeout string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "allinkl_dns_record" "www" {
+  zone_host   = "example.com"
+  record_type = "A"
+  record_name = "www"
+  record_data = %q
+  wait_for_propagation = {
+    nameservers = [%q]
+    timeout     = %q
+  }
+}
+`, data, server.DNSAddress(), timeout)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: lag,
+				Config:    config("192.0.2.1", "5m"),
+				Check:     waited,
+			},
+			{
+				PreConfig: lag,
+				Config:    config("192.0.2.2", "5m"),
+				Check:     resource.ComposeTestCheckFunc(waited, checkRecords(server, "example.com", "A www 192.0.2.2")),
+			},
+			{
+				PreConfig:   func() { server.SetDNSLag(true) },
+				Config:      config("192.0.2.3", "1s"),
+				ExpectError: regexp.MustCompile(`A record www.example.com not visible on`),
+			},
+		},
+		CheckDestroy: checkRecords(server, "example.com"),
+	})
+}
+
+func TestDNSRecordWaitForPropagationUnsupportedType(t *testing.T) {
+	server := newServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "allinkl_dns_record" "caa" {
+  zone_host            = "example.com"
+  record_type          = "CAA"
+  record_name          = ""
+  record_data          = "0 issue \"letsencrypt.org\""
+  wait_for_propagation = {}
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Waiting for propagation is not supported for CAA records`),
+			},
+		},
+	})
+}
+
+func TestDNSRecordCheckZoneSOA(t *testing.T) {
+	server := newServer(t)
+	defer provider.SetDefaultNameservers(server.DNSAddress())()
+
+	config := func(data string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "allinkl_dns_record" "www" {
+  zone_host      = "example.com"
+  record_type    = "A"
+  record_name    = "www"
+  record_data    = %q
+  check_zone_soa = true
+}
+`, data)
+	}
+	checkSerial := func(state *terraform.State) error {
+		return resource.TestCheckResourceAttr("allinkl_dns_record.www", "zone_soa_serial", fmt.Sprint(server.Serial("example.com")))(state)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("192.0.2.1"),
+				Check: resource.ComposeTestCheckFunc(
+					checkSerial,
+					resource.TestCheckResourceAttr("allinkl_dns_record.www", "zone_ttl", "3600"),
+				),
+			},
+			{
+				Config: config("192.0.2.2"),
+				Check:  checkSerial,
+			},
+		},
+	})
+}
+
+func TestDNSRecordCheckZoneSOAWarnings(t *testing.T) {
+	server := newServer(t)
+	// A nameserver serving an older serial of the zone, and one not
+	// serving it at all.
+	stale := newServer(t)
+	unrelated := allinkltest.NewServer("login", "password")
+	t.Cleanup(unrelated.Close)
+	defer provider.SetDefaultNameservers(server.DNSAddress(), stale.DNSAddress(), unrelated.DNSAddress())()
+
+	state, diags := newProtocolProvider(t, server, nil).apply("allinkl_dns_record", nil, map[string]any{
+		"zone_host":      "example.com",
+		"record_type":    "A",
+		"record_name":    "www",
+		"record_data":    "192.0.2.1",
+		"check_zone_soa": true,
+	})
+	if hasErrors(diags) {
+		t.Fatalf("create: %s", formatDiagnostics(diags))
+	}
+	for _, summary := range []string{"AllInkl DNS Zone Serial Mismatch", "AllInkl DNS Zone Not Served"} {
+		if !hasDiagnostic(diags, tfprotov6.DiagnosticSeverityWarning, summary) {
+			t.Errorf("want a %q warning, got %s", summary, formatDiagnostics(diags))
+		}
+	}
+	if serial, want := state.attribute(t, "zone_soa_serial"), fmt.Sprint(server.Serial("example.com")); serial != want {
+		t.Errorf("zone_soa_serial = %s, want the highest serial %s", serial, want)
+	}
+}
+
+func TestDNSRecordCreateOnly(t *testing.T) {
+	server := newServer(t)
+
+	config := func(data string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "allinkl_dns_record" "www" {
+  zone_host   = "example.com"
+  record_type = "A"
+  record_name = "www"
+  record_data = %q
+  create_only = true
+}
+`, data)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: allinkltest.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("192.0.2.1"),
+				Check:  checkRecords(server, "example.com", "A www 192.0.2.1"),
+			},
+			{
+				// Another system took the record over; that is no drift.
+				PreConfig: func() { changeRecordData(t, server, "example.com", "A", "www", "192.0.2.9") },
+				Config:    config("192.0.2.1"),
+				PlanOnly:  true,
+			},
+			{
+				// Configuration changes only reach the state.
+				Config: config("192.0.2.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("allinkl_dns_record.www", "record_data", "192.0.2.2"),
+					checkRecords(server, "example.com", "A www 192.0.2.9"),
+				),
+			},
+		},
+		// Destroying only removes the record from the state.
+		CheckDestroy: checkRecords(server, "example.com", "A www 192.0.2.9"),
+	})
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// zonefileRecordAttrTypes are the attribute types of a record returned by
+// parse_zonefile, matching the records of allinkl_dns_zone.
+var zonefileRecordAttrTypes = map[string]attr.Type{
+	"type": types.StringType,
+	"name": types.StringType,
+	"data": types.StringType,
+	"aux":  types.Int64Type,
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &parseZonefileFunction{}
+)
+
+// NewParseZonefileFunction is a helper function to simplify the provider implementation.
+func NewParseZonefileFunction() function.Function {
+	return &parseZonefileFunction{}
+}
+
+// parseZonefileFunction is the function implementation.
+type parseZonefileFunction struct{}
+
+// Metadata returns the function name.
+func (f *parseZonefileFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_zonefile"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseZonefileFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse BIND zone file text into DNS records",
+		MarkdownDescription: "Parses the text of a BIND zone file into a list of objects with `type`, `name`, `data` and `aux`, " +
+			"usable as `records` of `allinkl_dns_zone` or with `for_each` on `allinkl_dns`. Names are relative to the zone, " +
+			"empty for the apex; hostnames in record data are made absolute. SOA and apex NS records are skipped as KAS " +
+			"manages them. TTLs and classes are ignored. `$INCLUDE`, `$GENERATE` and record types KAS does not support " +
+			"are rejected.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "text",
+				MarkdownDescription: "The zone file text, e.g. from `file()`.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "origin",
+			MarkdownDescription: "The zone the file describes, needed if the file has no `$ORIGIN` directive. At most one value.",
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: zonefileRecordAttrTypes},
+		},
+	}
+}
+
+// Run returns the records of the zone file.
+func (f *parseZonefileFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text string
+	var origins []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &text, &origins))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(origins) > 1 {
+		resp.Error = function.NewArgumentFuncError(1, "At most one origin can be given.")
+		return
+	}
+	origin := ""
+	if len(origins) == 1 {
+		origin = origins[0]
+	}
+
+	records, err := parseZonefile(text, origin)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid zone file: "+err.Error())
+		return
+	}
+
+	values := make([]attr.Value, 0, len(records))
+	for _, record := range records {
+		values = append(values, types.ObjectValueMust(zonefileRecordAttrTypes, map[string]attr.Value{
+			"type": types.StringValue(record.Type),
+			"name": types.StringValue(record.Name),
+			"data": types.StringValue(record.Data),
+			"aux":  types.Int64Value(record.Aux),
+		}))
+	}
+
+	result := types.ListValueMust(types.ObjectType{AttrTypes: zonefileRecordAttrTypes}, values)
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &planDataSource{}
+	_ datasource.DataSourceWithConfigure = &planDataSource{}
+)
+
+// NewPlanDataSource is a helper function to simplify the provider implementation.
+func NewPlanDataSource() datasource.DataSource {
+	return &planDataSource{}
+}
+
+// planDataSource is the data source implementation.
+type planDataSource struct {
+	client       *allinkl.Client
+	providerData *allinklProviderData
+}
+
+// planDataSourceModel maps the data source schema data.
+type planDataSourceModel struct {
+	Limits   map[string]types.Int64 `tfsdk:"limits"`
+	Used     map[string]types.Int64 `tfsdk:"used"`
+	Features map[string]types.Bool  `tfsdk:"features"`
+}
+
+// Metadata returns the data source type name.
+func (d *planDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plan"
+}
+
+// Schema defines the schema for the data source.
+func (d *planDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the resources included in the All-Inkl package of the account, so modules can enable features " +
+			"conditionally, e.g. skip databases on packages without MySQL. Resources are keyed by their KAS name without the `max_` prefix, " +
+			"e.g. `database`, `mail_account` or `subdomain`.",
+		Attributes: map[string]schema.Attribute{
+			"limits": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The number of resources the package includes, `-1` if unlimited.",
+			},
+			"used": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The number of resources in use.",
+			},
+			"features": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.BoolType,
+				MarkdownDescription: "Whether the package includes the resource at all.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *planDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, recorder := d.providerData.withResponseRecorder(ctx)
+	defer appendDebugResponses(recorder, &resp.Diagnostics)
+
+	resources, err := d.client.GetAccountResources(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AllInkl Account Resources",
+			"Could not read the resources of the account package: "+d.providerData.kasErrorMessage(err),
+		)
+		return
+	}
+
+	state := planDataSourceModel{
+		Limits:   map[string]types.Int64{},
+		Used:     map[string]types.Int64{},
+		Features: map[string]types.Bool{},
+	}
+	for _, resource := range resources {
+		state.Limits[resource.Name] = types.Int64Value(resource.Max)
+		state.Used[resource.Name] = types.Int64Value(resource.Used)
+		state.Features[resource.Name] = types.BoolValue(resource.Max != 0)
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *planDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := providerDataFrom(req.ProviderData, &resp.Diagnostics)
+	if data == nil {
+		return
+	}
+
+	d.client = data.Client
+	d.providerData = data
+}
@@ -0,0 +1,251 @@
+package provider_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkltest"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// protocolProvider drives the provider over the plugin protocol like
+// Terraform does, for tests of warnings, which terraform-plugin-testing
+// does not expose, or of races between plan and apply.
+type protocolProvider struct {
+	t       *testing.T
+	server  tfprotov6.ProviderServer
+	schemas map[string]*tfprotov6.Schema
+}
+
+// resourceState is the state of a resource between protocol calls.
+type resourceState struct {
+	value   tftypes.Value
+	private []byte
+}
+
+// newProtocolProvider returns a provider configured to use server, with
+// the extra provider attributes in config, e.g. "debug_responses": true.
+func newProtocolProvider(t *testing.T, server *allinkltest.Server, config map[string]any) *protocolProvider {
+	t.Helper()
+
+	providerServer, err := allinkltest.ProtoV6ProviderFactories()["allinkl"]()
+	if err != nil {
+		t.Fatalf("creating provider server: %s", err)
+	}
+	ctx := context.Background()
+
+	schemas, err := providerServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil || hasErrors(schemas.Diagnostics) {
+		t.Fatalf("GetProviderSchema: %v %s", err, formatDiagnostics(schemas.Diagnostics))
+	}
+
+	values := map[string]any{
+		"username":      "login",
+		"password":      "password",
+		"api_endpoint":  server.APIEndpoint(),
+		"auth_endpoint": server.AuthEndpoint(),
+	}
+	for name, value := range config {
+		values[name] = value
+	}
+	configured, err := providerServer.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: dynamicValue(t, objectValue(schemas.Provider, values)),
+	})
+	if err != nil || hasErrors(configured.Diagnostics) {
+		t.Fatalf("ConfigureProvider: %v %s", err, formatDiagnostics(configured.Diagnostics))
+	}
+
+	return &protocolProvider{t: t, server: providerServer, schemas: schemas.ResourceSchemas}
+}
+
+// apply plans and applies changing the resource typeName from prior, nil
+// for a create, to config and returns the new state together with the
+// diagnostics of the plan and the apply. It stops the test if the plan
+// fails.
+func (p *protocolProvider) apply(typeName string, prior *resourceState, config map[string]any) (*resourceState, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	ctx := context.Background()
+
+	schema := p.schemas[typeName]
+	configValue := objectValue(schema, config)
+	priorValue := tftypes.NewValue(schema.ValueType(), nil)
+	var priorPrivate []byte
+	if prior != nil {
+		priorValue, priorPrivate = prior.value, prior.private
+	}
+
+	planned, err := p.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       dynamicValue(p.t, priorValue),
+		ProposedNewState: dynamicValue(p.t, proposedValue(schema, priorValue, configValue)),
+		Config:           dynamicValue(p.t, configValue),
+		PriorPrivate:     priorPrivate,
+	})
+	if err != nil || hasErrors(planned.Diagnostics) {
+		p.t.Fatalf("PlanResourceChange: %v %s", err, formatDiagnostics(planned.Diagnostics))
+	}
+
+	applied, err := p.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     dynamicValue(p.t, priorValue),
+		PlannedState:   planned.PlannedState,
+		Config:         dynamicValue(p.t, configValue),
+		PlannedPrivate: planned.PlannedPrivate,
+	})
+	if err != nil {
+		p.t.Fatalf("ApplyResourceChange: %s", err)
+	}
+
+	state := &resourceState{private: applied.Private}
+	if applied.NewState != nil {
+		state.value = unmarshalValue(p.t, applied.NewState, schema)
+	}
+	return state, append(planned.Diagnostics, applied.Diagnostics...)
+}
+
+// read refreshes state and returns the refreshed state together with the
+// diagnostics of the read.
+func (p *protocolProvider) read(typeName string, state *resourceState) (*resourceState, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+
+	read, err := p.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: dynamicValue(p.t, state.value),
+		Private:      state.private,
+	})
+	if err != nil {
+		p.t.Fatalf("ReadResource: %s", err)
+	}
+	return &resourceState{value: unmarshalValue(p.t, read.NewState, p.schemas[typeName]), private: read.Private}, read.Diagnostics
+}
+
+// attribute returns the string form of the top-level attribute name of
+// state, or "" if it is null.
+func (s *resourceState) attribute(t *testing.T, name string) string {
+	t.Helper()
+
+	var attributes map[string]tftypes.Value
+	if err := s.value.As(&attributes); err != nil {
+		t.Fatalf("decoding state: %s", err)
+	}
+	value := attributes[name]
+	if value.IsNull() || !value.IsKnown() {
+		return ""
+	}
+	switch {
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		_ = value.As(&b)
+		if b {
+			return "true"
+		}
+		return "false"
+	case value.Type().Is(tftypes.Number):
+		var n big.Float
+		_ = value.As(&n)
+		return n.String()
+	default:
+		var s string
+		if err := value.As(&s); err != nil {
+			t.Fatalf("decoding attribute %s: %s", name, err)
+		}
+		return s
+	}
+}
+
+// objectValue returns an object of the schema setting the attributes in
+// values and leaving every other attribute null. Values are strings,
+// bools, ints or string slices.
+func objectValue(schema *tfprotov6.Schema, values map[string]any) tftypes.Value {
+	objectType := schema.ValueType().(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = primitiveValue(attributeType, values[name])
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func primitiveValue(typ tftypes.Type, value any) tftypes.Value {
+	switch value := value.(type) {
+	case int:
+		return tftypes.NewValue(typ, big.NewFloat(float64(value)))
+	case []string:
+		elementType := typ.(tftypes.List).ElementType
+		elements := make([]tftypes.Value, 0, len(value))
+		for _, element := range value {
+			elements = append(elements, tftypes.NewValue(elementType, element))
+		}
+		return tftypes.NewValue(typ, elements)
+	default:
+		return tftypes.NewValue(typ, value)
+	}
+}
+
+// proposedValue merges config into prior like Terraform does: computed
+// attributes left null in config keep their prior value.
+func proposedValue(schema *tfprotov6.Schema, prior, config tftypes.Value) tftypes.Value {
+	if prior.IsNull() {
+		return config
+	}
+
+	var priorAttributes, attributes map[string]tftypes.Value
+	_ = prior.As(&priorAttributes)
+	_ = config.As(&attributes)
+	for _, attribute := range schema.Block.Attributes {
+		if attribute.Computed && attributes[attribute.Name].IsNull() {
+			attributes[attribute.Name] = priorAttributes[attribute.Name]
+		}
+	}
+	return tftypes.NewValue(config.Type(), attributes)
+}
+
+func dynamicValue(t *testing.T, value tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	dynamic, err := tfprotov6.NewDynamicValue(value.Type(), value)
+	if err != nil {
+		t.Fatalf("encoding %s: %s", value, err)
+	}
+	return &dynamic
+}
+
+func unmarshalValue(t *testing.T, dynamic *tfprotov6.DynamicValue, schema *tfprotov6.Schema) tftypes.Value {
+	t.Helper()
+
+	value, err := dynamic.Unmarshal(schema.ValueType())
+	if err != nil {
+		t.Fatalf("decoding state: %s", err)
+	}
+	return value
+}
+
+func hasErrors(diagnostics []*tfprotov6.Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDiagnostic reports whether diagnostics hold one of severity with the
+// given summary.
+func hasDiagnostic(diagnostics []*tfprotov6.Diagnostic, severity tfprotov6.DiagnosticSeverity, summary string) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == severity && diagnostic.Summary == summary {
+			return true
+		}
+	}
+	return false
+}
+
+func formatDiagnostics(diagnostics []*tfprotov6.Diagnostic) string {
+	var lines []string
+	for _, diagnostic := range diagnostics {
+		lines = append(lines, diagnostic.Severity.String()+": "+diagnostic.Summary+": "+diagnostic.Detail)
+	}
+	return strings.Join(lines, "\n")
+}
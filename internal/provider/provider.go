@@ -3,26 +3,51 @@ package provider
 import (
 	"context"
 	"os"
-	"terraform-provider-allinkl/internal/allinkl"
+	"strings"
+	"time"
 
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// defaultEnvPrefix prefixes the environment variables the provider reads
+// unless env_prefix is set.
+const defaultEnvPrefix = "ALLINKL"
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &allinklProvider{}
+	_ provider.Provider              = &allinklProvider{}
+	_ provider.ProviderWithFunctions = &allinklProvider{}
 )
 
 // allinklProviderModel maps provider schema data to a Go type.
 type allinklProviderModel struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Username       types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	DebugResponses types.Bool   `tfsdk:"debug_responses"`
+	RefreshMaxAge  types.String `tfsdk:"refresh_max_age"`
+	Locale         types.String `tfsdk:"locale"`
+	APIEndpoint    types.String `tfsdk:"api_endpoint"`
+	AuthEndpoint   types.String `tfsdk:"auth_endpoint"`
+	EnvPrefix      types.String `tfsdk:"env_prefix"`
+
+	TrackLastUpdated types.Bool `tfsdk:"track_last_updated"`
+	StrictDecoding   types.Bool `tfsdk:"strict_decoding"`
+
+	ProtectSystemRecords types.Bool   `tfsdk:"protect_system_records"`
+	MinRequestInterval   types.String `tfsdk:"min_request_interval"`
+	AutoAdopt            types.Bool   `tfsdk:"auto_adopt"`
+	QuotaPreflight       types.Bool   `tfsdk:"quota_preflight"`
+
+	Lease *leaseModel `tfsdk:"lease"`
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -54,11 +79,108 @@ func (p *allinklProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				Optional: true,
+				Optional:            true,
+				MarkdownDescription: "The KAS login. May also be provided via the ALLINKL_USERNAME environment variable.",
 			},
 			"password": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The KAS password. May also be provided via the ALLINKL_PASSWORD environment variable.",
+			},
+			"env_prefix": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Prefix of the environment variables the provider reads, e.g. `ALLINKL_PROD` to read ALLINKL_PROD_USERNAME, " +
+					"ALLINKL_PROD_PASSWORD, ALLINKL_PROD_API_ENDPOINT and ALLINKL_PROD_AUTH_ENDPOINT, so aliased providers can use different " +
+					"accounts. Defaults to `" + defaultEnvPrefix + "`.",
+			},
+			"debug_responses": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Attach the decoded KAS API responses (credentials redacted) to warning diagnostics. Intended for troubleshooting only.",
+			},
+			"refresh_max_age": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Skip the KAS lookup during refresh for DNS records the provider read or wrote within this duration, e.g. `1h`. " +
+					"Speeds up plans of large zones at the cost of detecting out-of-band changes later. Defaults to always reading.",
+				Validators: []validator.String{
+					duration(),
+				},
+			},
+			"locale": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Language of the translations of common KAS messages in diagnostics, `en` or `de`. " +
+					"The original KAS message is always included. Defaults to `en`.",
+				Validators: []validator.String{
+					stringOneOf(localeEnglish, localeGerman),
+				},
+			},
+			"api_endpoint": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "URL of the KAS API, e.g. of a mock server in tests. May also be provided via the ALLINKL_API_ENDPOINT " +
+					"environment variable. Defaults to the All-Inkl KAS API.",
+			},
+			"auth_endpoint": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "URL of the KAS authentication API, e.g. of a mock server in tests. May also be provided via the " +
+					"ALLINKL_AUTH_ENDPOINT environment variable. Defaults to the All-Inkl KAS authentication API.",
+			},
+			"track_last_updated": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Set `last_updated` of `allinkl_dns` records to the time the provider last created or changed them. " +
+					"Set to `false` to leave `last_updated` null and keep it out of plans. Defaults to `true`.",
+			},
+			"strict_decoding": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Fail requests whose KAS responses contain fields the provider does not know instead of ignoring them, " +
+					"to detect KAS API changes early. Intended for non-production workspaces. Defaults to `false`.",
+			},
+			"min_request_interval": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Minimum time between two KAS requests, e.g. `2s`, for accounts where KAS keeps asking the provider " +
+					"to slow down. The provider suggests a value when flood delays add up during an apply. Defaults to the delay KAS requests.",
+				Validators: []validator.String{
+					duration(),
+				},
+			},
+			"protect_system_records": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Reject plans that delete or replace NS and SOA records managed through the provider, as a single " +
+					"bad apply could take the zone or its subdomains offline. Set to `false` for the run meant to delete them. Defaults to `true`.",
+			},
+			"auto_adopt": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Adopt an identical existing record into the state with a warning instead of failing when creating " +
+					"`allinkl_dns` and `allinkl_dns_record` resources, e.g. when re-running a partially failed apply. Applies to resources " +
+					"that do not set `allow_adopt`. Defaults to `false`.",
+			},
+			"quota_preflight": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Check the resources of the account package while planning the creation of mail accounts and fail " +
+					"the plan with e.g. `quota exceeded: 10/10 mail accounts` instead of failing halfway through the apply. Creations planned " +
+					"together count against the quota as a whole. Costs one KAS request per planned creation. Defaults to `false`.",
+			},
+			"lease": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Hold an advisory lease on the account while changing it, so two Terraform runs sharing the " +
+					"account cannot change records at the same time. The lease is a `" + leaseRecordName + "` TXT record in `zone`; " +
+					"runs finding a lease of another run fail with a diagnostic naming its holder.",
+				Attributes: map[string]schema.Attribute{
+					"zone": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The zone holding the lease record. All runs sharing the account must use the same zone.",
+					},
+					"holder": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of this run shown to runs waiting for the lease, e.g. a team or CI job. Defaults to the hostname and process ID.",
+					},
+					"duration": schema.StringAttribute{
+						Optional: true,
+						MarkdownDescription: "How long the lease is held after the last change, e.g. `15m`. Terraform does not tell providers " +
+							"when a run ends, so the lease expires instead of being released. Defaults to `10m`.",
+						Validators: []validator.String{
+							positiveDuration(),
+						},
+					},
+				},
 			},
 		},
 	}
@@ -79,12 +201,24 @@ func (p *allinklProvider) Configure(ctx context.Context, req provider.ConfigureR
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 
+	if config.EnvPrefix.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("env_prefix"),
+			"Unknown AllInkl Environment Variable Prefix",
+			"The provider cannot read its environment variables as there is an unknown configuration value for env_prefix. "+
+				"Set the value statically in the configuration.",
+		)
+		return
+	}
+	usernameEnv := envVariable(config.EnvPrefix.ValueString(), "USERNAME")
+	passwordEnv := envVariable(config.EnvPrefix.ValueString(), "PASSWORD")
+
 	if config.Username.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("username"),
 			"Unknown AllInkl API Username",
 			"The provider cannot create the AllInkl API client as there is an unknown configuration value for the AllInkl API username. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the ALLINKL_USERNAME environment variable.",
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the "+usernameEnv+" environment variable.",
 		)
 	}
 
@@ -93,7 +227,7 @@ func (p *allinklProvider) Configure(ctx context.Context, req provider.ConfigureR
 			path.Root("password"),
 			"Unknown AllInkl API Password",
 			"The provider cannot create the AllInkl API client as there is an unknown configuration value for the AllInkl API password. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the ALLINKL_PASSWORD environment variable.",
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the "+passwordEnv+" environment variable.",
 		)
 	}
 
@@ -104,8 +238,8 @@ func (p *allinklProvider) Configure(ctx context.Context, req provider.ConfigureR
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
-	username := os.Getenv("ALLINKL_USERNAME")
-	password := os.Getenv("ALLINKL_PASSWORD")
+	username := os.Getenv(usernameEnv)
+	password := os.Getenv(passwordEnv)
 
 	if !config.Username.IsNull() {
 		username = config.Username.ValueString()
@@ -123,7 +257,7 @@ func (p *allinklProvider) Configure(ctx context.Context, req provider.ConfigureR
 			path.Root("username"),
 			"Missing AllInkl API Username",
 			"The provider cannot create the AllInkl API client as there is a missing or empty value for the AllInkl API username. "+
-				"Set the username value in the configuration or use the ALLINKL_USERNAME environment variable. "+
+				"Set the username value in the configuration or use the "+usernameEnv+" environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -133,7 +267,7 @@ func (p *allinklProvider) Configure(ctx context.Context, req provider.ConfigureR
 			path.Root("password"),
 			"Missing AllInkl API Password",
 			"The provider cannot create the AllInkl API client as there is a missing or empty value for the AllInkl API password. "+
-				"Set the password value in the configuration or use the ALLINKL_PASSWORD environment variable. "+
+				"Set the password value in the configuration or use the "+passwordEnv+" environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -150,22 +284,95 @@ func (p *allinklProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 	var client = allinkl.NewClient(username, password)
 
+	apiEndpoint := os.Getenv(envVariable(config.EnvPrefix.ValueString(), "API_ENDPOINT"))
+	authEndpoint := os.Getenv(envVariable(config.EnvPrefix.ValueString(), "AUTH_ENDPOINT"))
+
+	if !config.APIEndpoint.IsNull() {
+		apiEndpoint = config.APIEndpoint.ValueString()
+	}
+
+	if !config.AuthEndpoint.IsNull() {
+		authEndpoint = config.AuthEndpoint.ValueString()
+	}
+
+	if apiEndpoint != "" || authEndpoint != "" {
+		if apiEndpoint == "" {
+			apiEndpoint = client.APIEndpoint()
+		}
+		if authEndpoint == "" {
+			authEndpoint = client.AuthEndpoint()
+		}
+		client = allinkl.NewClientWithEndpoints(username, password, apiEndpoint, authEndpoint)
+	}
+	client.StrictDecoding = config.StrictDecoding.ValueBool()
+	if !config.MinRequestInterval.IsNull() {
+		client.MinRequestInterval, _ = time.ParseDuration(config.MinRequestInterval.ValueString())
+	}
+
+	var data = &allinklProviderData{
+		Client:         client,
+		Version:        p.version,
+		DebugResponses: config.DebugResponses.ValueBool(),
+		Locale:         config.Locale.ValueString(),
+
+		TrackLastUpdated:     config.TrackLastUpdated.IsNull() || config.TrackLastUpdated.ValueBool(),
+		ProtectSystemRecords: config.ProtectSystemRecords.IsNull() || config.ProtectSystemRecords.ValueBool(),
+		AutoAdopt:            config.AutoAdopt.ValueBool(),
+		QuotaPreflight:       config.QuotaPreflight.ValueBool(),
+
+		FloodAdvisor: &floodAdvisor{},
+		MailForwards: &plannedMailForwards{},
+
+		QuotaReservations: &quotaReservations{},
+	}
+	client.EventHandler = data.handleClientEvent
+
+	if !config.RefreshMaxAge.IsNull() {
+		data.RefreshMaxAge, _ = time.ParseDuration(config.RefreshMaxAge.ValueString())
+	}
+
+	if config.Lease != nil {
+		lease, err := newAccountLease(*config.Lease)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Configure AllInkl Account Lease",
+				"Could not generate a run ID for the account lease: "+err.Error(),
+			)
+			return
+		}
+		data.Lease = lease
+	}
+
 	// Make the AllInkl client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = data
+	resp.ResourceData = data
 
 	tflog.Info(ctx, "Configured AllInkl client", map[string]any{"success": true})
 }
 
-func (p *allinklProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{
-		// NewCoffeesDataSource,
+// envVariable returns the name of the environment variable holding the
+// setting name, e.g. ALLINKL_PROD_USERNAME for prefix ALLINKL_PROD and name
+// USERNAME. An empty prefix uses the default prefix.
+func envVariable(prefix, name string) string {
+	prefix = strings.TrimSuffix(prefix, "_")
+	if prefix == "" {
+		prefix = defaultEnvPrefix
 	}
+	return prefix + "_" + name
+}
+
+func (p *allinklProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return registeredDataSources()
 }
 
 func (p *allinklProvider) Resources(_ context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
-		NewDNSResource,
+	return registeredResources()
+}
+
+func (p *allinklProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewNormalizeTXTFunction,
+		NewParseZonefileFunction,
 	}
 }
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// allinklProviderData is made available to data sources and resources
+// during their Configure methods.
+type allinklProviderData struct {
+	Client *allinkl.Client
+
+	// Version is the version of the provider binary.
+	Version string
+
+	// DebugResponses attaches the decoded KAS responses to warning
+	// diagnostics.
+	DebugResponses bool
+
+	// RefreshMaxAge skips remote reads during refresh for records fetched
+	// or written within this duration. Zero always reads.
+	RefreshMaxAge time.Duration
+
+	// Locale is the language KAS messages are translated into in
+	// diagnostics.
+	Locale string
+
+	// TrackLastUpdated sets last_updated of DNS records when they are
+	// created or changed. Otherwise last_updated stays null.
+	TrackLastUpdated bool
+
+	// ProtectSystemRecords rejects plans deleting NS and SOA records.
+	ProtectSystemRecords bool
+
+	// AutoAdopt adopts identical existing records on create when the
+	// resource does not set allow_adopt.
+	AutoAdopt bool
+
+	// QuotaPreflight checks the resources of the account package when
+	// planning resources counted against it.
+	QuotaPreflight bool
+
+	// FloodAdvisor sums up the flood delays of the run.
+	FloodAdvisor *floodAdvisor
+
+	// MailForwards collects the mail forwards planned in the run.
+	MailForwards *plannedMailForwards
+
+	// QuotaReservations holds the creations planned in the run that
+	// quota_preflight counts against the account package.
+	QuotaReservations *quotaReservations
+
+	// Lease, if set, is acquired before every change to the account.
+	Lease *accountLease
+}
+
+// trackLastUpdated reports whether last_updated is tracked. It defaults to
+// true before the provider is configured.
+func (d *allinklProviderData) trackLastUpdated() bool {
+	return d == nil || d.TrackLastUpdated
+}
+
+// protectSystemRecords reports whether plans deleting NS and SOA records are
+// rejected. It defaults to true before the provider is configured.
+func (d *allinklProviderData) protectSystemRecords() bool {
+	return d == nil || d.ProtectSystemRecords
+}
+
+// adopt reports whether an identical existing record is adopted on create,
+// given the allow_adopt value of the resource.
+func (d *allinklProviderData) adopt(allowAdopt types.Bool) bool {
+	if !allowAdopt.IsNull() {
+		return allowAdopt.ValueBool()
+	}
+	return d != nil && d.AutoAdopt
+}
+
+// lastUpdatedValue returns the last_updated value of a record changed now.
+func (d *allinklProviderData) lastUpdatedValue() types.String {
+	if !d.trackLastUpdated() {
+		return types.StringNull()
+	}
+	return types.StringValue(time.Now().UTC().Format(time.RFC3339))
+}
+
+// withResponseRecorder returns a context recording KAS responses if
+// debug_responses is enabled, and the recorder in use (nil otherwise).
+func (d *allinklProviderData) withResponseRecorder(ctx context.Context) (context.Context, *allinkl.ResponseRecorder) {
+	if d == nil || !d.DebugResponses {
+		return ctx, nil
+	}
+
+	recorder := &allinkl.ResponseRecorder{}
+	return allinkl.WithResponseRecorder(ctx, recorder), recorder
+}
+
+// appendDebugResponses adds a warning diagnostic for every response captured
+// by recorder.
+func appendDebugResponses(recorder *allinkl.ResponseRecorder, diags *diag.Diagnostics) {
+	if recorder == nil {
+		return
+	}
+
+	for i, response := range recorder.Responses() {
+		detail, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			detail = []byte(fmt.Sprintf("%#v", response))
+		}
+		diags.AddWarning(
+			fmt.Sprintf("AllInkl API Response #%d", i+1),
+			"debug_responses is enabled on the provider. Decoded KAS response (credentials redacted):\n\n"+string(detail),
+		)
+	}
+}
+
+// handleClientEvent logs the waits of the KAS client and sums up its flood
+// delays.
+func (d *allinklProviderData) handleClientEvent(ctx context.Context, event allinkl.Event) {
+	logClientEvent(ctx, event)
+	if d.FloodAdvisor != nil {
+		d.FloodAdvisor.observe(event)
+	}
+}
+
+// logClientEvent logs the waits of the KAS client, so debug logs show where
+// time goes during slow applies.
+func logClientEvent(ctx context.Context, event allinkl.Event) {
+	fields := map[string]any{
+		"action":  event.Action,
+		"attempt": event.Attempt,
+		"wait":    event.Wait.String(),
+	}
+
+	switch event.Kind {
+	case allinkl.EventFloodDelay:
+		tflog.Debug(ctx, "Waiting for KAS flood delay", fields)
+	case allinkl.EventRetry:
+		fields["error"] = event.Err.Error()
+		tflog.Debug(ctx, "Retrying KAS request after transient error", fields)
+	}
+}
+
+// providerDataFrom converts the ProviderData handed to Configure methods,
+// reporting a diagnostic if it is of an unexpected type.
+func providerDataFrom(providerData any, diags *diag.Diagnostics) *allinklProviderData {
+	data, ok := providerData.(*allinklProviderData)
+	if !ok {
+		diags.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *allinklProviderData, got: %T. Please report this issue to the provider developers.", providerData),
+		)
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// protocolVersion is the Terraform plugin protocol version the provider
+	// is served with.
+	protocolVersion = 6
+
+	// redactedEndpointPart replaces credentials and query parameters of
+	// reported endpoints.
+	redactedEndpointPart = "REDACTED"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &providerInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &providerInfoDataSource{}
+)
+
+// NewProviderInfoDataSource is a helper function to simplify the provider implementation.
+func NewProviderInfoDataSource() datasource.DataSource {
+	return &providerInfoDataSource{}
+}
+
+// providerInfoDataSource is the data source implementation.
+type providerInfoDataSource struct {
+	providerData *allinklProviderData
+}
+
+// providerInfoDataSourceModel maps the data source schema data.
+type providerInfoDataSourceModel struct {
+	Version         types.String `tfsdk:"version"`
+	ProtocolVersion types.Int64  `tfsdk:"protocol_version"`
+	APIEndpoint     types.String `tfsdk:"api_endpoint"`
+	AuthEndpoint    types.String `tfsdk:"auth_endpoint"`
+	DebugResponses  types.Bool   `tfsdk:"debug_responses"`
+	RefreshMaxAge   types.String `tfsdk:"refresh_max_age"`
+	Locale          types.String `tfsdk:"locale"`
+
+	TrackLastUpdated types.Bool `tfsdk:"track_last_updated"`
+	StrictDecoding   types.Bool `tfsdk:"strict_decoding"`
+
+	ProtectSystemRecords types.Bool `tfsdk:"protect_system_records"`
+	AutoAdopt            types.Bool `tfsdk:"auto_adopt"`
+	QuotaPreflight       types.Bool `tfsdk:"quota_preflight"`
+}
+
+// Metadata returns the data source type name.
+func (d *providerInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_info"
+}
+
+// Schema defines the schema for the data source.
+func (d *providerInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the provider build and the effective provider configuration, for support requests and comparing pinned versions across workspaces.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version of the provider binary, `dev` for local builds.",
+			},
+			"protocol_version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Terraform plugin protocol version the provider is served with.",
+			},
+			"api_endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "KAS API endpoint, with credentials and query parameters redacted.",
+			},
+			"auth_endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "KAS authentication endpoint, with credentials and query parameters redacted.",
+			},
+			"debug_responses": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether `debug_responses` is enabled.",
+			},
+			"refresh_max_age": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The effective `refresh_max_age`, `0s` if refreshes always read.",
+			},
+			"locale": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The effective `locale` of diagnostics.",
+			},
+			"track_last_updated": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether `last_updated` of DNS records is tracked.",
+			},
+			"strict_decoding": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether `strict_decoding` is enabled.",
+			},
+			"protect_system_records": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether plans deleting NS and SOA records are rejected.",
+			},
+			"auto_adopt": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether identical existing records are adopted on create by default.",
+			},
+			"quota_preflight": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether planned creations are checked against the resources of the account package.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *providerInfoDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.providerData == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured AllInkl Provider",
+			"The provider has not been configured yet. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	state := providerInfoDataSourceModel{
+		Version:         types.StringValue(d.providerData.Version),
+		ProtocolVersion: types.Int64Value(protocolVersion),
+		APIEndpoint:     types.StringValue(redactEndpoint(d.providerData.Client.APIEndpoint())),
+		AuthEndpoint:    types.StringValue(redactEndpoint(d.providerData.Client.AuthEndpoint())),
+		DebugResponses:  types.BoolValue(d.providerData.DebugResponses),
+		RefreshMaxAge:   types.StringValue(d.providerData.RefreshMaxAge.String()),
+		Locale:          types.StringValue(d.providerData.locale()),
+
+		TrackLastUpdated: types.BoolValue(d.providerData.TrackLastUpdated),
+		StrictDecoding:   types.BoolValue(d.providerData.Client.StrictDecoding),
+
+		ProtectSystemRecords: types.BoolValue(d.providerData.ProtectSystemRecords),
+		AutoAdopt:            types.BoolValue(d.providerData.AutoAdopt),
+		QuotaPreflight:       types.BoolValue(d.providerData.QuotaPreflight),
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *providerInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = providerDataFrom(req.ProviderData, &resp.Diagnostics)
+}
+
+// redactEndpoint removes credentials and query parameters from an endpoint
+// URL.
+func redactEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return redactedEndpointPart
+	}
+	if u.User != nil {
+		u.User = url.User(redactedEndpointPart)
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = redactedEndpointPart
+	}
+	u.Fragment = ""
+	return u.String()
+}
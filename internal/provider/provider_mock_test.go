@@ -0,0 +1,49 @@
+package provider_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestProviderDebugResponses(t *testing.T) {
+	server := newServer(t)
+	server.ExtraResponseFields = map[string]any{"session_token": "leaked-token"}
+
+	config := map[string]any{
+		"zone_host":   "example.com",
+		"record_type": "A",
+		"record_name": "www",
+		"record_data": "192.0.2.1",
+	}
+	debugResponses := func(diagnostics []*tfprotov6.Diagnostic) []string {
+		var details []string
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Severity == tfprotov6.DiagnosticSeverityWarning && strings.HasPrefix(diagnostic.Summary, "AllInkl API Response #") {
+				details = append(details, diagnostic.Detail)
+			}
+		}
+		return details
+	}
+
+	_, diags := newProtocolProvider(t, server, map[string]any{"debug_responses": true}).apply("allinkl_dns_record", nil, config)
+	if hasErrors(diags) {
+		t.Fatalf("create: %s", formatDiagnostics(diags))
+	}
+	details := debugResponses(diags)
+	if !strings.Contains(strings.Join(details, "\n"), `"KasRequestType": "add_dns_settings"`) {
+		t.Errorf("debug responses %q, want the add_dns_settings response", details)
+	}
+	for _, detail := range details {
+		if strings.Contains(detail, "leaked-token") || !strings.Contains(detail, `"session_token": "REDACTED"`) {
+			t.Errorf("debug response %q, want session_token redacted", detail)
+		}
+	}
+
+	config["record_name"] = "api"
+	_, diags = newProtocolProvider(t, server, nil).apply("allinkl_dns_record", nil, config)
+	if details := debugResponses(diags); hasErrors(diags) || len(details) != 0 {
+		t.Errorf("create without debug_responses: want no debug responses, got %s", formatDiagnostics(diags))
+	}
+}
@@ -23,3 +23,17 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestEnvVariable(t *testing.T) {
+	t.Parallel()
+
+	for prefix, want := range map[string]string{
+		"":              "ALLINKL_USERNAME",
+		"ALLINKL_PROD":  "ALLINKL_PROD_USERNAME",
+		"ALLINKL_PROD_": "ALLINKL_PROD_USERNAME",
+	} {
+		if got := envVariable(prefix, "USERNAME"); got != want {
+			t.Errorf("envVariable(%q): got %q, want %q", prefix, got, want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// quotaReservations holds the creations planned in the run per resource
+// name, e.g. mail_account. Terraform plans every resource on its own, so
+// without them several creations planned together would each be checked
+// against the quota alone.
+type quotaReservations struct {
+	mu      sync.Mutex
+	pending map[string]map[string]bool
+}
+
+// reserve records the planned creation identified by key and returns the
+// number of other creations of name planned in the run.
+func (q *quotaReservations) reserve(name, key string) int {
+	if q == nil {
+		return 0
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pending == nil {
+		q.pending = map[string]map[string]bool{}
+	}
+	if q.pending[name] == nil {
+		q.pending[name] = map[string]bool{}
+	}
+	q.pending[name][key] = true
+	return len(q.pending[name]) - 1
+}
+
+// release removes the planned creation identified by key once it is
+// carried out, as the account resources then count it.
+func (q *quotaReservations) release(name, key string) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.pending[name], key)
+}
+
+// checkQuota returns an error if the package of the account has no room for
+// another resource named name, e.g. mail_account, on top of the other
+// creations of name planned in the run, so a plan fails instead of the apply
+// running into a KAS fault halfway. key identifies the planned resource, e.g.
+// by its address. It does nothing unless quota_preflight is enabled.
+func (d *allinklProviderData) checkQuota(ctx context.Context, name, description, key string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if d == nil || !d.QuotaPreflight {
+		return diags
+	}
+
+	planned := int64(d.QuotaReservations.reserve(name, key))
+
+	resources, err := d.Client.GetAccountResources(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to Check AllInkl Quota",
+			"Could not read the resources of the account package for quota_preflight: "+d.kasErrorMessage(err),
+		)
+		return diags
+	}
+
+	if err := quotaExceeded(resources, name, description, planned); err != nil {
+		diags.AddError(
+			"AllInkl Quota Exceeded",
+			err.Error()+". Delete unused "+description+" or upgrade the package of the account before applying.",
+		)
+	}
+	return diags
+}
+
+// releaseQuota releases the reservation checkQuota made for key.
+func (d *allinklProviderData) releaseQuota(name, key string) {
+	if d != nil {
+		d.QuotaReservations.release(name, key)
+	}
+}
+
+// quotaExceeded returns an error like "quota exceeded: 25/25 databases" if
+// the resource named name is used up, counting planned further creations as
+// used. Resources the package does not report or includes without limit are
+// never exceeded.
+func quotaExceeded(resources []allinkl.AccountResource, name, description string, planned int64) error {
+	for _, resource := range resources {
+		if resource.Name != name || resource.Max < 0 {
+			continue
+		}
+		if resource.Used+planned >= resource.Max {
+			if planned > 0 {
+				return fmt.Errorf("quota exceeded: %d/%d %s with %d more planned in this run", resource.Used, resource.Max, description, planned)
+			}
+			return fmt.Errorf("quota exceeded: %d/%d %s", resource.Used, resource.Max, description)
+		}
+	}
+	return nil
+}
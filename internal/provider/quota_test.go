@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+)
+
+func TestQuotaExceeded(t *testing.T) {
+	t.Parallel()
+
+	resources := []allinkl.AccountResource{
+		{Name: "database", Max: 25, Used: 25},
+		{Name: "mail_account", Max: 10, Used: 3},
+		{Name: "mail_forward", Max: -1, Used: 400},
+		{Name: "subdomain", Max: 0, Used: 0},
+	}
+
+	testCases := map[string]struct {
+		name    string
+		planned int64
+		wantErr string
+	}{
+		"used-up":     {name: "database", wantErr: "quota exceeded: 25/25 databases"},
+		"room-left":   {name: "mail_account"},
+		"unlimited":   {name: "mail_forward"},
+		"not-booked":  {name: "subdomain", wantErr: "quota exceeded: 0/0 subdomains"},
+		"unreported":  {name: "domain"},
+		"planned":     {name: "mail_account", planned: 7, wantErr: "quota exceeded: 3/10 mail_accounts with 7 more planned in this run"},
+		"planned-fit": {name: "mail_account", planned: 6},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := quotaExceeded(resources, testCase.name, testCase.name+"s", testCase.planned)
+			switch {
+			case testCase.wantErr == "" && err != nil:
+				t.Errorf("quotaExceeded() = %v, want nil", err)
+			case testCase.wantErr != "" && (err == nil || err.Error() != testCase.wantErr):
+				t.Errorf("quotaExceeded() = %v, want %q", err, testCase.wantErr)
+			}
+		})
+	}
+}
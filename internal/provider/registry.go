@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// resourceRegistration registers a resource type with the provider.
+type resourceRegistration struct {
+	// name is the type name without the provider prefix, e.g. dns_record.
+	// The Metadata of the resource must report the same name.
+	name    string
+	factory func() resource.Resource
+
+	// importable is set for resources supporting terraform import.
+	importable bool
+}
+
+// dataSourceRegistration registers a data source type with the provider.
+type dataSourceRegistration struct {
+	// name is the type name without the provider prefix, e.g. dns_records.
+	// The Metadata of the data source must report the same name.
+	name    string
+	factory func() datasource.DataSource
+}
+
+// resourceRegistry lists the resource types of the provider. New resources
+// are added here; registry_test.go checks the entries against the
+// implementations.
+var resourceRegistry = []resourceRegistration{
+	{name: "dns_record", factory: NewDNSRecordResource, importable: true},
+	{name: "dns", factory: NewDNSResource, importable: true},
+	{name: "dns_txt_challenge", factory: NewDNSTXTChallengeResource, importable: true},
+	{name: "dns_record_set", factory: NewDNSRecordSetResource, importable: true},
+	{name: "dns_zone", factory: NewDNSZoneResource, importable: true},
+	{name: "dns_zone_snapshot", factory: NewDNSZoneSnapshotResource},
+	{name: "dns_zone_restore", factory: NewDNSZoneRestoreResource},
+	{name: "mail_account", factory: NewMailAccountResource, importable: true},
+	{name: "mail_forward", factory: NewMailForwardResource, importable: true},
+}
+
+// dataSourceRegistry lists the data source types of the provider.
+var dataSourceRegistry = []dataSourceRegistration{
+	{name: "dns_record", factory: NewDNSRecordDataSource},
+	{name: "dns_lookup", factory: NewDNSLookupDataSource},
+	{name: "dns_records", factory: NewDNSRecordsDataSource},
+	{name: "dns_reverse_lookup", factory: NewDNSReverseLookupDataSource},
+	{name: "dns_zone", factory: NewDNSZoneDataSource},
+	{name: "dns_zone_exists", factory: NewDNSZoneExistsDataSource},
+	{name: "dns_zone_import", factory: NewDNSZoneImportDataSource},
+	{name: "dns_zones", factory: NewDNSZonesDataSource},
+	{name: "dns_zonefile", factory: NewDNSZonefileDataSource},
+	{name: "plan", factory: NewPlanDataSource},
+	{name: "provider_info", factory: NewProviderInfoDataSource},
+}
+
+// registeredResources returns the factories of the registered resources.
+func registeredResources() []func() resource.Resource {
+	factories := make([]func() resource.Resource, 0, len(resourceRegistry))
+	for _, registration := range resourceRegistry {
+		factories = append(factories, registration.factory)
+	}
+	return factories
+}
+
+// registeredDataSources returns the factories of the registered data
+// sources.
+func registeredDataSources() []func() datasource.DataSource {
+	factories := make([]func() datasource.DataSource, 0, len(dataSourceRegistry))
+	for _, registration := range dataSourceRegistry {
+		factories = append(factories, registration.factory)
+	}
+	return factories
+}
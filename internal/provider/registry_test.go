@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestResourceRegistry(t *testing.T) {
+	t.Parallel()
+
+	names := map[string]bool{}
+	for _, registration := range resourceRegistry {
+		if names[registration.name] {
+			t.Errorf("resource %s is registered twice", registration.name)
+		}
+		names[registration.name] = true
+
+		r := registration.factory()
+
+		var metadata resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "allinkl"}, &metadata)
+		if want := "allinkl_" + registration.name; metadata.TypeName != want {
+			t.Errorf("resource %s: Metadata reports type name %s, want %s", registration.name, metadata.TypeName, want)
+		}
+
+		var schema resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schema)
+		if schema.Diagnostics.HasError() {
+			t.Errorf("resource %s: Schema: %v", registration.name, schema.Diagnostics)
+		}
+		if schema.Schema.MarkdownDescription == "" && schema.Schema.Description == "" {
+			t.Errorf("resource %s: schema has no description", registration.name)
+		}
+
+		if _, ok := r.(resource.ResourceWithImportState); ok != registration.importable {
+			t.Errorf("resource %s: implements ImportState = %t, registered as importable = %t", registration.name, ok, registration.importable)
+		}
+	}
+}
+
+func TestDataSourceRegistry(t *testing.T) {
+	t.Parallel()
+
+	names := map[string]bool{}
+	for _, registration := range dataSourceRegistry {
+		if names[registration.name] {
+			t.Errorf("data source %s is registered twice", registration.name)
+		}
+		names[registration.name] = true
+
+		d := registration.factory()
+
+		var metadata datasource.MetadataResponse
+		d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "allinkl"}, &metadata)
+		if want := "allinkl_" + registration.name; metadata.TypeName != want {
+			t.Errorf("data source %s: Metadata reports type name %s, want %s", registration.name, metadata.TypeName, want)
+		}
+
+		var schema datasource.SchemaResponse
+		d.Schema(context.Background(), datasource.SchemaRequest{}, &schema)
+		if schema.Diagnostics.HasError() {
+			t.Errorf("data source %s: Schema: %v", registration.name, schema.Diagnostics)
+		}
+		if schema.Schema.MarkdownDescription == "" && schema.Schema.Description == "" {
+			t.Errorf("data source %s: schema has no description", registration.name)
+		}
+	}
+}
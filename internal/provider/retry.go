@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/ViMaSter/terraform-provider-allinkl/allinkl"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMinBackoff  = time.Second
+	defaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// retryModel maps the retry attribute of resources.
+type retryModel struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff  types.String `tfsdk:"min_backoff"`
+	MaxBackoff  types.String `tfsdk:"max_backoff"`
+}
+
+// retrySchemaAttribute returns the schema of the retry attribute.
+func retrySchemaAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Retries KAS requests failing with transient errors such as network errors, 502, 503 and 504 responses or flood protection faults. " +
+			"Requests creating records, mail accounts or mail forwards are only retried after flood protection faults, as KAS may have " +
+			"carried them out even though the response was lost. Without it, requests are not retried.",
+		Attributes: map[string]schema.Attribute{
+			"max_attempts": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Total number of attempts per request. Defaults to `3`.",
+				Validators: []validator.Int64{
+					int64AtLeast(1),
+				},
+			},
+			"min_backoff": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Delay before the first retry, doubling for each further retry. Defaults to `1s`.",
+				Validators: []validator.String{
+					duration(),
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Upper bound of the delay between retries. Defaults to `30s`.",
+				Validators: []validator.String{
+					duration(),
+				},
+			},
+		},
+	}
+}
+
+// withRetry returns ctx retrying transient KAS errors according to m. Without
+// a configured retry attribute ctx is returned unchanged.
+func withRetry(ctx context.Context, m *retryModel) context.Context {
+	if m == nil {
+		return ctx
+	}
+
+	policy := allinkl.RetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		MinBackoff:  durationValueOr(m.MinBackoff, defaultRetryMinBackoff),
+		MaxBackoff:  durationValueOr(m.MaxBackoff, defaultRetryMaxBackoff),
+	}
+	if !m.MaxAttempts.IsNull() && !m.MaxAttempts.IsUnknown() {
+		policy.MaxAttempts = int(m.MaxAttempts.ValueInt64())
+	}
+
+	return allinkl.WithRetryPolicy(ctx, policy)
+}
+
+// durationValueOr parses v, falling back to def if v is not set or invalid.
+func durationValueOr(v types.String, def time.Duration) time.Duration {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	d, err := time.ParseDuration(v.ValueString())
+	if err != nil {
+		return def
+	}
+	return d
+}
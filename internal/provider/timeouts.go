@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsModel maps the timeouts attribute of resources.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsSchemaAttribute returns the schema of the timeouts attribute.
+func timeoutsSchemaAttribute() schema.SingleNestedAttribute {
+	operation := func(name string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Deadline for the " + name + " operation, e.g. `2m`. Each KAS request of the operation also times out after 30 seconds on its own.",
+			Validators: []validator.String{
+				duration(),
+			},
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Operation deadlines covering all KAS requests and flood delays of an operation.",
+		Attributes: map[string]schema.Attribute{
+			"create": operation("create"),
+			"read":   operation("read"),
+			"update": operation("update"),
+			"delete": operation("delete"),
+		},
+	}
+}
+
+// withTimeout returns ctx bounded by the configured timeout of the given
+// operation value. Without a configured timeout ctx is returned unchanged.
+func withTimeout(ctx context.Context, timeout types.String) (context.Context, context.CancelFunc) {
+	if timeout.IsNull() || timeout.IsUnknown() {
+		return ctx, func() {}
+	}
+
+	d, err := time.ParseDuration(timeout.ValueString())
+	if err != nil || d <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, d)
+}
+
+// create returns the create timeout, null if timeouts is not configured.
+func (m *timeoutsModel) create() types.String {
+	if m == nil {
+		return types.StringNull()
+	}
+	return m.Create
+}
+
+// read returns the read timeout, null if timeouts is not configured.
+func (m *timeoutsModel) read() types.String {
+	if m == nil {
+		return types.StringNull()
+	}
+	return m.Read
+}
+
+// update returns the update timeout, null if timeouts is not configured.
+func (m *timeoutsModel) update() types.String {
+	if m == nil {
+		return types.StringNull()
+	}
+	return m.Update
+}
+
+// delete returns the delete timeout, null if timeouts is not configured.
+func (m *timeoutsModel) delete() types.String {
+	if m == nil {
+		return types.StringNull()
+	}
+	return m.Delete
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ validator.String = stringOneOfValidator{}
+	_ validator.String = durationValidator{}
+	_ validator.Int64  = int64AtLeastValidator{}
+	_ validator.Set    = setSizeAtLeastValidator{}
+)
+
+// stringOneOfValidator validates that a string attribute is one of a fixed
+// set of values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+// stringOneOf returns a validator which ensures the configured value matches
+// one of the given values exactly.
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+// durationValidator validates that a string attribute is a Go duration.
+type durationValidator struct {
+	positive bool
+}
+
+// duration returns a validator which ensures the configured value parses as
+// a duration such as "30s" or "5m".
+func duration() validator.String {
+	return durationValidator{}
+}
+
+// positiveDuration returns a validator which ensures the configured value
+// parses as a duration greater than zero.
+func positiveDuration() validator.String {
+	return durationValidator{positive: true}
+}
+
+func (v durationValidator) Description(_ context.Context) string {
+	if v.positive {
+		return "value must be a positive duration such as \"30s\" or \"5m\""
+	}
+	return "value must be a duration such as \"30s\" or \"5m\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d < 0 || (v.positive && d == 0) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// int64AtLeastValidator validates that an int64 attribute is at least a
+// minimum value.
+type int64AtLeastValidator struct {
+	min int64
+}
+
+// int64AtLeast returns a validator which ensures the configured value is at
+// least min.
+func int64AtLeast(min int64) validator.Int64 {
+	return int64AtLeastValidator{min: min}
+}
+
+func (v int64AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64AtLeastValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), req.ConfigValue.ValueInt64()),
+		)
+	}
+}
+
+// setSizeAtLeastValidator validates that a set attribute has at least a
+// minimum number of elements.
+type setSizeAtLeastValidator struct {
+	min int
+}
+
+// setSizeAtLeast returns a validator which ensures the configured set has at
+// least min elements.
+func setSizeAtLeast(min int) validator.Set {
+	return setSizeAtLeastValidator{min: min}
+}
+
+func (v setSizeAtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("set must contain at least %d elements", v.min)
+}
+
+func (v setSizeAtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v setSizeAtLeastValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if len(req.ConfigValue.Elements()) < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), len(req.ConfigValue.Elements())),
+		)
+	}
+}
@@ -7,8 +7,8 @@ import (
 	"context"
 	"flag"
 	"log"
-	"terraform-provider-allinkl/internal/provider"
 
+	"github.com/ViMaSter/terraform-provider-allinkl/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 